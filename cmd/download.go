@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/xdagiz/xytz/internal/config"
+	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	exitFailed      = 1
+	exitUsage       = 2
+	exitInterrupted = 130
+)
+
+var (
+	downloadFormat             string
+	downloadPreset             string
+	downloadAudio              bool
+	downloadABR                float64
+	downloadOutput             string
+	downloadJSON               bool
+	downloadCookiesFromBrowser string
+	downloadCookies            string
+	downloadEmbedSubs          bool
+	downloadEmbedMetadata      bool
+	downloadEmbedChapters      bool
+	downloadLiveFromStart      bool
+	downloadWaitForVideo       bool
+	downloadPlaylistItems      string
+	downloadDateAfter          string
+	downloadDateBefore         string
+
+	downloadCmd = &cobra.Command{
+		Use:   "download <url...>",
+		Short: "Download videos without starting the TUI",
+		Long: `Download one or more videos or playlists non-interactively using the
+same yt-dlp options as the TUI. Progress is printed to stdout as plain
+text or, with --json, as one JSON object per line.
+
+Exit codes: 0 on success, 1 if any download failed, 2 on invalid usage
+and 130 when interrupted.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runDownloadCmd,
+	}
+)
+
+type downloadEvent struct {
+	Event       string   `json:"event"`
+	Index       int      `json:"index"`
+	Total       int      `json:"total"`
+	URL         string   `json:"url"`
+	Percent     float64  `json:"percent,omitempty"`
+	Speed       string   `json:"speed,omitempty"`
+	Eta         string   `json:"eta,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Phase       string   `json:"phase,omitempty"`
+	Destination string   `json:"destination,omitempty"`
+	Item        int      `json:"item,omitempty"`
+	Items       int      `json:"items,omitempty"`
+	VideoID     string   `json:"video_id,omitempty"`
+	Downloaded  int64    `json:"downloaded_bytes,omitempty"`
+	TotalBytes  int64    `json:"total_bytes,omitempty"`
+	Live        bool     `json:"live,omitempty"`
+	Elapsed     float64  `json:"elapsed,omitempty"`
+	Files       []string `json:"files,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+type downloadPrinter struct {
+	out         io.Writer
+	json        bool
+	mutex       sync.Mutex
+	lastPercent int
+	lastElapsed int
+	lastPhase   string
+}
+
+const liveReportInterval = 10
+
+func (p *downloadPrinter) print(ev downloadEvent) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.json {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(p.out, string(data))
+		return
+	}
+
+	switch ev.Event {
+	case "start":
+		p.lastPercent = -1
+		p.lastElapsed = -1
+		p.lastPhase = ""
+		fmt.Fprintf(p.out, "[%d/%d] %s\n", ev.Index, ev.Total, ev.URL)
+	case "progress":
+		if ev.Phase != "" && ev.Phase != string(types.PhaseDownloading) {
+			if ev.Phase != p.lastPhase {
+				p.lastPhase = ev.Phase
+				fmt.Fprintf(p.out, "  %s\n", ev.Phase)
+			}
+			return
+		}
+		p.lastPhase = ev.Phase
+
+		if ev.Live {
+			elapsed := int(ev.Elapsed) / liveReportInterval
+			if elapsed == p.lastElapsed {
+				return
+			}
+			p.lastElapsed = elapsed
+			fmt.Fprintf(p.out, "  ● recording %s  %s  %s\n", utils.FormatDuration(ev.Elapsed), utils.FormatBytes(float64(ev.Downloaded)), ev.Speed)
+			return
+		}
+
+		percent := int(math.Floor(ev.Percent))
+		if percent == p.lastPercent {
+			return
+		}
+		p.lastPercent = percent
+		if ev.Items > 0 {
+			fmt.Fprintf(p.out, "  item %d/%d  %5.1f%%  %s  ETA %s\n", ev.Item, ev.Items, ev.Percent, ev.Speed, ev.Eta)
+			return
+		}
+		fmt.Fprintf(p.out, "  %5.1f%%  %s  ETA %s\n", ev.Percent, ev.Speed, ev.Eta)
+	case "done":
+		if len(ev.Files) == 0 {
+			fmt.Fprintf(p.out, "  ✓ done\n")
+		}
+		for _, file := range ev.Files {
+			fmt.Fprintf(p.out, "  ✓ %s\n", file)
+		}
+	case "error":
+		fmt.Fprintf(p.out, "  ✕ %s\n", ev.Error)
+	}
+}
+
+func runDownloadCmd(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		cmd.Usage()
+		return &exitError{code: exitUsage, err: fmt.Errorf("no URL given")}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	if logger := openDebugLog(); logger != nil {
+		defer logger.Close()
+	}
+
+	format := downloadFormat
+	isAudio := downloadAudio
+	audioFormat := ""
+	if downloadPreset != "" {
+		if cmd.Flags().Changed("format") || downloadAudio {
+			return &exitError{code: exitUsage, err: fmt.Errorf("--preset cannot be combined with --format or --audio")}
+		}
+
+		preset, ok := cfg.FindPreset(downloadPreset)
+		if !ok {
+			return &exitError{code: exitUsage, err: fmt.Errorf("unknown preset %q (available: %s)", downloadPreset, strings.Join(cfg.PresetNames(), ", "))}
+		}
+
+		format = preset.Format
+		isAudio = preset.IsAudio()
+		audioFormat = preset.AudioFormat
+	} else if !cmd.Flags().Changed("format") {
+		if downloadAudio {
+			format = "bestaudio/best"
+		} else {
+			format = cfg.DefaultFormat
+		}
+	}
+
+	options := types.DownloadOptions()
+	for i := range options {
+		switch options[i].ConfigField {
+		case "EmbedSubtitles":
+			options[i].Enabled = flagOrDefault(cmd, "embed-subs", downloadEmbedSubs, cfg.EmbedSubtitles)
+		case "EmbedMetadata":
+			options[i].Enabled = flagOrDefault(cmd, "embed-metadata", downloadEmbedMetadata, cfg.EmbedMetadata)
+		case "EmbedChapters":
+			options[i].Enabled = flagOrDefault(cmd, "embed-chapters", downloadEmbedChapters, cfg.EmbedChapters)
+		case "LiveFromStart":
+			options[i].Enabled = flagOrDefault(cmd, "live-from-start", downloadLiveFromStart, cfg.LiveFromStart)
+		case "WaitForVideo":
+			options[i].Enabled = flagOrDefault(cmd, "wait-for-video", downloadWaitForVideo, cfg.WaitForVideo)
+		}
+	}
+
+	reqs := make([]types.DownloadRequest, len(args))
+	for i, url := range args {
+		req := types.DownloadRequest{
+			URL:                url,
+			FormatID:           format,
+			IsAudioTab:         isAudio,
+			ABR:                downloadABR,
+			AudioFormat:        audioFormat,
+			Title:              url,
+			Options:            options,
+			CookiesFromBrowser: downloadCookiesFromBrowser,
+			Cookies:            downloadCookies,
+			OutputPath:         downloadOutput,
+			PlaylistItems:      downloadPlaylistItems,
+			DateAfter:          downloadDateAfter,
+			DateBefore:         downloadDateBefore,
+		}
+		req.Collection = req.PlaylistItems != "" || req.DateAfter != "" || req.DateBefore != ""
+		reqs[i] = req
+	}
+
+	printer := &downloadPrinter{out: cmd.OutOrStdout(), json: downloadJSON}
+	failed, interrupted := runDownloads(printer, reqs)
+	if interrupted {
+		return &exitError{code: exitInterrupted, err: fmt.Errorf("interrupted")}
+	}
+
+	if failed > 0 {
+		return &exitError{code: exitFailed, err: fmt.Errorf("%d of %d downloads failed", failed, len(args))}
+	}
+
+	return nil
+}
+
+func runDownloads(printer *downloadPrinter, reqs []types.DownloadRequest) (failed int, interrupted bool) {
+	dm := utils.NewDownloadManager()
+	var interruptMutex sync.Mutex
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	go func() {
+		if _, ok := <-sigs; ok {
+			interruptMutex.Lock()
+			interrupted = true
+			interruptMutex.Unlock()
+			dm.Cancel()
+		}
+	}()
+
+	for i, req := range reqs {
+		interruptMutex.Lock()
+		stop := interrupted
+		interruptMutex.Unlock()
+		if stop {
+			break
+		}
+
+		index := i + 1
+		url := req.URL
+		printer.print(downloadEvent{Event: "start", Index: index, Total: len(reqs), URL: url})
+
+		result := utils.RunDownload(dm, req, func(msg types.ProgressMsg) {
+			printer.print(downloadEvent{
+				Event:       "progress",
+				Index:       index,
+				Total:       len(reqs),
+				URL:         url,
+				Percent:     msg.Percent,
+				Speed:       msg.Speed,
+				Eta:         msg.Eta,
+				Status:      msg.Status,
+				Phase:       string(msg.Phase),
+				Destination: msg.Destination,
+				Item:        msg.ItemIndex,
+				Items:       msg.ItemCount,
+				VideoID:     msg.VideoID,
+				Downloaded:  msg.DownloadedBytes,
+				TotalBytes:  msg.TotalBytes,
+				Live:        msg.Live,
+				Elapsed:     msg.Elapsed,
+			})
+		})
+
+		if result.Err != "" {
+			failed++
+			printer.print(downloadEvent{Event: "error", Index: index, Total: len(reqs), URL: url, Error: result.Err})
+		} else {
+			printer.print(downloadEvent{Event: "done", Index: index, Total: len(reqs), URL: url, Files: result.FilePaths})
+		}
+	}
+
+	interruptMutex.Lock()
+	defer interruptMutex.Unlock()
+
+	return failed, interrupted
+}
+
+func flagOrDefault(cmd *cobra.Command, name string, value, fallback bool) bool {
+	if cmd.Flags().Changed(name) {
+		return value
+	}
+
+	return fallback
+}
+
+func init() {
+	downloadCmd.Flags().StringVarP(&downloadFormat, "format", "f", "", "yt-dlp format expression (defaults to default_format from config)")
+	downloadCmd.Flags().StringVarP(&downloadPreset, "preset", "P", "", "Named format preset from config (e.g. 1080p-mp4, audio-opus)")
+	downloadCmd.Flags().BoolVarP(&downloadAudio, "audio", "x", false, "Extract audio and convert it to mp3")
+	downloadCmd.Flags().Float64Var(&downloadABR, "abr", 0, "Audio bitrate in kbps when using --audio (0 for best)")
+	downloadCmd.Flags().StringVarP(&downloadOutput, "output", "o", "", "Download directory (defaults to default_download_path from config)")
+	downloadCmd.Flags().BoolVar(&downloadJSON, "json", false, "Print progress as JSON lines")
+
+	downloadCmd.Flags().StringVar(&downloadCookiesFromBrowser, "cookies-from-browser", "", "The name of the browser to load cookies from")
+	downloadCmd.Flags().StringVar(&downloadCookies, "cookies", "", "Netscape formatted file to read cookies from")
+
+	downloadCmd.Flags().BoolVar(&downloadEmbedSubs, "embed-subs", false, "Embed subtitles (defaults to embed_subtitles from config)")
+	downloadCmd.Flags().BoolVar(&downloadEmbedMetadata, "embed-metadata", false, "Embed metadata (defaults to embed_metadata from config)")
+	downloadCmd.Flags().BoolVar(&downloadEmbedChapters, "embed-chapters", false, "Embed chapters (defaults to embed_chapters from config)")
+	downloadCmd.Flags().BoolVar(&downloadLiveFromStart, "live-from-start", false, "Record live streams from their start (defaults to live_from_start from config)")
+	downloadCmd.Flags().BoolVar(&downloadWaitForVideo, "wait-for-video", false, "Wait for scheduled streams to start (defaults to wait_for_video from config)")
+
+	downloadCmd.Flags().StringVar(&downloadPlaylistItems, "playlist-items", "", "Playlist or channel entries to download, e.g. 1:10,15 or -5:")
+	downloadCmd.Flags().StringVar(&downloadDateAfter, "date-after", "", "Only download entries uploaded on or after this date (YYYYMMDD or today-2weeks)")
+	downloadCmd.Flags().StringVar(&downloadDateBefore, "date-before", "", "Only download entries uploaded on or before this date (YYYYMMDD or today-2weeks)")
+
+	rootCmd.AddCommand(downloadCmd)
+}
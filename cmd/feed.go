@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/xdagiz/xytz/internal/config"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	feedCmdLimit   int
+	feedCmdJSON    bool
+	feedCmdNewOnly bool
+
+	feedCmd = &cobra.Command{
+		Use:   "feed",
+		Short: "Print the latest uploads of subscribed channels",
+		Long: `Print the latest uploads of the channels subscribed with /subscribe.
+Results use the same format as ` + "`xytz search`" + `, with an extra "new" column
+(or field, with --json) for uploads that were not seen on the last check.
+
+Exit codes: 0 on success, 1 if the feed could not be loaded.`,
+		Args:          usageArgs(cobra.NoArgs),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runFeedCmd,
+	}
+)
+
+func runFeedCmd(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	if logger := openDebugLog(); logger != nil {
+		defer logger.Close()
+	}
+
+	limit := feedCmdLimit
+	if !cmd.Flags().Changed("number") {
+		limit = cfg.SearchLimit
+	}
+
+	result := utils.FetchFeed(utils.NewSearchManager(), limit)
+	if result.Cancelled {
+		return &exitError{code: exitFailed, err: fmt.Errorf("feed was cancelled")}
+	}
+
+	if result.Err != "" {
+		return &exitError{code: exitFailed, err: fmt.Errorf("%s", result.Err)}
+	}
+
+	out := cmd.OutOrStdout()
+	for _, item := range result.Videos {
+		record, ok := newSearchRecord(item)
+		if !ok {
+			continue
+		}
+
+		isNew := result.NewIDs[record.ID]
+		if feedCmdNewOnly && !isNew {
+			continue
+		}
+
+		record.New = &isNew
+		if err := printSearchRecord(out, record, feedCmdJSON); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	feedCmd.Flags().IntVarP(&feedCmdLimit, "number", "n", 0, "Number of uploads per channel (defaults to search_limit from config)")
+	feedCmd.Flags().BoolVar(&feedCmdJSON, "json", false, "Print results as JSON lines")
+	feedCmd.Flags().BoolVar(&feedCmdNewOnly, "new", false, "Only print uploads that are new since the last check")
+
+	rootCmd.AddCommand(feedCmd)
+}
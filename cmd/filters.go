@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/xdagiz/xytz/internal/types"
+
+	"github.com/spf13/cobra"
+)
+
+type searchFilterFlags struct {
+	uploadDate string
+	duration   string
+	resultType string
+	features   []string
+}
+
+func (f *searchFilterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.uploadDate, "upload-date", "", "Only results uploaded within this period (hour, today, week, month, year)")
+	cmd.Flags().StringVar(&f.duration, "duration", "", "Only results of this length (short, medium, long)")
+	cmd.Flags().StringVar(&f.resultType, "type", "", "Only results of this type (video, channel, playlist)")
+	cmd.Flags().StringSliceVar(&f.features, "features", nil, "Only results with all of these features (hd, 4k, subtitles, live, cc)")
+}
+
+func (f searchFilterFlags) parse() (types.SearchFilters, error) {
+	return types.ParseSearchFilters(f.uploadDate, f.duration, f.resultType, f.features)
+}
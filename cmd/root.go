@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/xdagiz/xytz/internal/app"
 	"github.com/xdagiz/xytz/internal/config"
 	"github.com/xdagiz/xytz/internal/models"
 	"github.com/xdagiz/xytz/internal/paths"
+	"github.com/xdagiz/xytz/internal/types"
 
 	tea "github.com/charmbracelet/bubbletea"
 	zone "github.com/lrstanley/bubblezone"
@@ -20,9 +24,12 @@ var (
 	sortBy             string
 	query              string
 	channel            string
+	channelTab         string
 	playlist           string
 	cookiesFromBrowser string
 	cookies            string
+	preset             string
+	filterFlags        searchFilterFlags
 
 	rootCmd = &cobra.Command{
 		Use:   "xytz",
@@ -47,14 +54,41 @@ browse, and download videos directly from your terminal.`,
 )
 
 func startApp() {
+	if preset != "" {
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = config.GetDefault()
+		}
+
+		if _, ok := cfg.FindPreset(preset); !ok {
+			fmt.Fprintf(os.Stderr, "Error: unknown preset %q (available: %s)\n", preset, strings.Join(cfg.PresetNames(), ", "))
+			os.Exit(exitUsage)
+		}
+	}
+
+	filters, err := filterFlags.parse()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitUsage)
+	}
+
+	tab, err := types.ParseChannelTab(channelTab)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(exitUsage)
+	}
+
 	opts := &models.CLIOptions{
 		SearchLimit:        searchLimit,
 		SortBy:             sortBy,
+		Filters:            filters,
 		Query:              query,
 		Channel:            channel,
+		ChannelTab:         tab,
 		Playlist:           playlist,
 		CookiesFromBrowser: cookiesFromBrowser,
 		Cookies:            cookies,
+		Preset:             preset,
 	}
 
 	zone.NewGlobal()
@@ -64,6 +98,23 @@ func startApp() {
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	m.Program = p
 
+	if logger := openDebugLog(); logger != nil {
+		defer logger.Close()
+	}
+
+	if _, err := p.Run(); err != nil {
+		log.Fatal("unable to run the app")
+		os.Exit(1)
+	}
+
+	m.SearchManager.Cancel()
+	m.FormatsManager.Cancel()
+	m.DownloadQueue.CancelAll()
+
+	saveConfigOptions(m)
+}
+
+func openDebugLog() *os.File {
 	logDir := paths.GetDataDir()
 	if err := paths.EnsureDirExists(logDir); err != nil {
 		log.Printf("Warning: Could not create log directory: %v", err)
@@ -75,25 +126,49 @@ func startApp() {
 	logger, err := tea.LogToFile(logPath, "debug")
 	if err != nil {
 		log.Printf("Warning: Could not create debug log file: %v", err)
-	} else {
-		defer logger.Close()
+		return nil
 	}
 
-	if _, err := p.Run(); err != nil {
-		log.Fatal("unable to run the app")
-		os.Exit(1)
-	}
+	return logger
+}
 
-	m.SearchManager.Cancel()
-	m.FormatsManager.Cancel()
-	m.DownloadManager.Cancel()
+type exitError struct {
+	code int
+	err  error
+}
 
-	saveConfigOptions(m)
+func (e *exitError) Error() string {
+	return e.err.Error()
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+	cmd, err := rootCmd.ExecuteC()
+	if err == nil {
+		return
+	}
+
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) {
+		exitErr = &exitError{code: exitFailed, err: err}
+	}
+
+	fmt.Fprintln(os.Stderr, "Error:", exitErr.err)
+	if exitErr.code == exitUsage {
+		fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+	}
+	os.Exit(exitErr.code)
+}
+
+func usageError(_ *cobra.Command, err error) error {
+	return &exitError{code: exitUsage, err: err}
+}
+
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError(cmd, err)
+		}
+		return nil
 	}
 }
 
@@ -104,18 +179,27 @@ func init() {
 		cfg = config.GetDefault()
 	}
 
+	rootCmd.SilenceErrors = true
+	rootCmd.Args = usageArgs(cobra.NoArgs)
+	rootCmd.SetFlagErrorFunc(usageError)
+
 	rootCmd.Flags().IntVarP(&searchLimit, "number", "n", cfg.SearchLimit, "Number of search results")
 
 	rootCmd.Flags().StringVarP(&sortBy, "sort-by", "s", cfg.SortByDefault, "Default sort option (relevance, date, views, rating)")
 
+	filterFlags.register(rootCmd)
+
 	rootCmd.Flags().BoolP("help", "h", false, "Help for xytz")
 
 	rootCmd.Flags().StringVarP(&query, "query", "q", "", "Direct search with a query")
 	rootCmd.Flags().StringVarP(&channel, "channel", "c", "", "Direct channel search")
+	rootCmd.Flags().StringVar(&channelTab, "tab", "", "Channel tab to list with --channel (videos, shorts, streams)")
 	rootCmd.Flags().StringVarP(&playlist, "playlist", "p", "", "Direct playlist search")
 
 	rootCmd.Flags().StringVarP(&cookiesFromBrowser, "cookies-from-browser", "", cfg.CookiesBrowser, "The name of the browser to load cookies from")
 	rootCmd.Flags().StringVarP(&cookies, "cookies", "", cfg.CookiesFile, "Netscape formatted file to read cookies from")
+
+	rootCmd.Flags().StringVar(&preset, "preset", "", "Download with this format preset without showing the format list")
 }
 
 func saveConfigOptions(m *app.Model) {
@@ -133,6 +217,10 @@ func saveConfigOptions(m *app.Model) {
 			cfg.EmbedMetadata = opt.Enabled
 		case "EmbedChapters":
 			cfg.EmbedChapters = opt.Enabled
+		case "LiveFromStart":
+			cfg.LiveFromStart = opt.Enabled
+		case "WaitForVideo":
+			cfg.WaitForVideo = opt.Enabled
 		}
 	}
 
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/config"
+	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchCmdLimit    int
+	searchCmdSortBy   string
+	searchCmdChannel  bool
+	searchCmdTab      string
+	searchCmdPlaylist bool
+	searchCmdJSON     bool
+	searchCmdFilters  searchFilterFlags
+
+	searchCmd = &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search YouTube without starting the TUI",
+		Long: `Search YouTube, a channel or a playlist and print the results to stdout.
+Results are printed as tab separated values (id, title, views, duration in
+seconds, channel) or, with --json, as one JSON object per line. Channels
+and playlists found by a search are printed with their ID and name, and
+zero views and duration; the JSON objects tell them apart by type.
+
+Exit codes: 0 on success, 1 if the search failed and 2 on invalid usage.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runSearchCmd,
+	}
+)
+
+type searchRecord struct {
+	Type        string  `json:"type"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	URL         string  `json:"url"`
+	Views       float64 `json:"views"`
+	Duration    float64 `json:"duration"`
+	Channel     string  `json:"channel"`
+	Kind        string  `json:"kind,omitempty"`
+	Subscribers float64 `json:"subscribers,omitempty"`
+	VideoCount  float64 `json:"video_count,omitempty"`
+	New         *bool   `json:"new,omitempty"`
+}
+
+func newSearchRecord(item list.Item) (searchRecord, bool) {
+	switch item := item.(type) {
+	case types.VideoItem:
+		return searchRecord{
+			Type:     "video",
+			ID:       item.ID,
+			Title:    item.VideoTitle,
+			URL:      "https://www.youtube.com/watch?v=" + item.ID,
+			Views:    item.Views,
+			Duration: item.Duration,
+			Channel:  item.Channel,
+			Kind:     string(item.Kind),
+		}, true
+	case types.ChannelItem:
+		channel := item.Handle
+		if channel == "" {
+			channel = item.ID
+		}
+		return searchRecord{
+			Type:        "channel",
+			ID:          item.ID,
+			Title:       item.Name,
+			URL:         strings.TrimSuffix(utils.ChannelURL(channel), "/videos"),
+			Channel:     item.Name,
+			Subscribers: item.Subscribers,
+			VideoCount:  item.VideoCount,
+		}, true
+	case types.PlaylistItem:
+		return searchRecord{
+			Type:       "playlist",
+			ID:         item.ID,
+			Title:      item.PlaylistTitle,
+			URL:        utils.PlaylistURL(item.ID),
+			Channel:    item.Channel,
+			VideoCount: item.VideoCount,
+		}, true
+	default:
+		return searchRecord{}, false
+	}
+}
+
+func runSearchCmd(cmd *cobra.Command, args []string) error {
+	input := strings.TrimSpace(strings.Join(args, " "))
+	if input == "" {
+		cmd.Usage()
+		return &exitError{code: exitUsage, err: fmt.Errorf("no query given")}
+	}
+
+	if searchCmdChannel && searchCmdPlaylist {
+		return &exitError{code: exitUsage, err: fmt.Errorf("--channel and --playlist cannot be used together")}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	if logger := openDebugLog(); logger != nil {
+		defer logger.Close()
+	}
+
+	limit := searchCmdLimit
+	if !cmd.Flags().Changed("number") {
+		limit = cfg.SearchLimit
+	}
+
+	sortBy := types.ParseSortBy(cfg.SortByDefault)
+	if cmd.Flags().Changed("sort-by") {
+		if sortBy, err = types.ParseSortOption(searchCmdSortBy); err != nil {
+			return &exitError{code: exitUsage, err: err}
+		}
+	}
+
+	filters, err := searchCmdFilters.parse()
+	if err != nil {
+		return &exitError{code: exitUsage, err: err}
+	}
+
+	tab, err := types.ParseChannelTab(searchCmdTab)
+	if err != nil {
+		return &exitError{code: exitUsage, err: err}
+	}
+
+	sm := utils.NewSearchManager()
+
+	var search func() any
+	switch {
+	case searchCmdChannel:
+		channelName := utils.ExtractChannelUsername(input)
+		search = func() any { return utils.PerformChannelSearch(sm, channelName, tab, limit)() }
+	case searchCmdPlaylist:
+		search = func() any { return utils.PerformPlaylistSearch(sm, input, limit)() }
+	default:
+		search = func() any {
+			return utils.PerformSearch(sm, input, types.GetSPParam(sortBy, filters), limit)()
+		}
+	}
+
+	var result types.SearchResultMsg
+	switch msg := search().(type) {
+	case types.SearchResultMsg:
+		result = msg
+	case types.StartFormatMsg:
+		return &exitError{code: exitUsage, err: fmt.Errorf("%q is a video URL, use `xytz download` instead", input)}
+	default:
+		return &exitError{code: exitFailed, err: fmt.Errorf("search was cancelled")}
+	}
+
+	if result.Err != "" {
+		return &exitError{code: exitFailed, err: fmt.Errorf("%s", result.Err)}
+	}
+
+	out := cmd.OutOrStdout()
+	for _, item := range result.Videos {
+		record, ok := newSearchRecord(item)
+		if !ok {
+			continue
+		}
+
+		if err := printSearchRecord(out, record, searchCmdJSON); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printSearchRecord(out io.Writer, record searchRecord, asJSON bool) error {
+	if asJSON {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+
+	clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	line := fmt.Sprintf("%s\t%s\t%.0f\t%.0f\t%s", record.ID, clean.Replace(record.Title), record.Views, record.Duration, clean.Replace(record.Channel))
+	if record.New != nil {
+		line += fmt.Sprintf("\t%t", *record.New)
+	}
+
+	_, err := fmt.Fprintln(out, line)
+	return err
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchCmdLimit, "number", "n", 0, "Number of search results (defaults to search_limit from config)")
+	searchCmd.Flags().StringVarP(&searchCmdSortBy, "sort-by", "s", "", "Sort option (relevance, date, views, rating)")
+	searchCmd.Flags().BoolVarP(&searchCmdChannel, "channel", "c", false, "Treat the query as a channel (@username, channel ID or URL)")
+	searchCmd.Flags().StringVar(&searchCmdTab, "tab", "", "Channel tab to list with --channel (videos, shorts, streams)")
+	searchCmd.Flags().BoolVarP(&searchCmdPlaylist, "playlist", "p", false, "Treat the query as a playlist ID or URL")
+	searchCmd.Flags().BoolVar(&searchCmdJSON, "json", false, "Print results as JSON lines")
+	searchCmdFilters.register(searchCmd)
+
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/xdagiz/xytz/internal/config"
+	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncJSON   bool
+	syncDryRun bool
+
+	syncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Run the auto-download rules from the config",
+		Long: `Evaluate the auto_download_rules from the config and download every new
+upload that matches them. Videos that are already in the download archive
+are skipped, so sync can be run from cron as often as needed.
+
+Exit codes: 0 on success, 1 if any rule failed and 130 when interrupted.`,
+		Args:          usageArgs(cobra.NoArgs),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runSyncCmd,
+	}
+)
+
+func runSyncCmd(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	if logger := openDebugLog(); logger != nil {
+		defer logger.Close()
+	}
+
+	jobs, errs := utils.SyncJobs(cfg)
+	for _, err := range errs {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error:", err)
+	}
+
+	if len(cfg.AutoDownloadRules) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No auto_download_rules configured in", config.GetConfigPath())
+	}
+
+	if syncDryRun {
+		for _, job := range jobs {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\t%s\n", job.Rule.Label(), job.Request.URL, job.Request.FormatID, job.Request.MatchFilter)
+		}
+		return syncExitError(len(errs), len(errs)+len(jobs))
+	}
+
+	reqs := make([]types.DownloadRequest, len(jobs))
+	for i, job := range jobs {
+		reqs[i] = job.Request
+	}
+
+	printer := &downloadPrinter{out: cmd.OutOrStdout(), json: syncJSON}
+	failed, interrupted := runDownloads(printer, reqs)
+	if interrupted {
+		return &exitError{code: exitInterrupted, err: fmt.Errorf("interrupted")}
+	}
+
+	return syncExitError(failed+len(errs), len(errs)+len(jobs))
+}
+
+func syncExitError(failed, total int) error {
+	if failed > 0 {
+		return &exitError{code: exitFailed, err: fmt.Errorf("%d of %d sync jobs failed", failed, total)}
+	}
+
+	return nil
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncJSON, "json", false, "Print progress as JSON lines")
+	syncCmd.Flags().BoolVarP(&syncDryRun, "dry-run", "n", false, "Only print what would be downloaded")
+
+	rootCmd.AddCommand(syncCmd)
+}
@@ -1,8 +1,7 @@
 package app
 
 import (
-	"strings"
-
+	"github.com/xdagiz/xytz/internal/config"
 	"github.com/xdagiz/xytz/internal/models"
 	"github.com/xdagiz/xytz/internal/styles"
 	"github.com/xdagiz/xytz/internal/types"
@@ -14,27 +13,46 @@ import (
 )
 
 type Model struct {
-	Program         *tea.Program
-	Search          models.SearchModel
-	State           types.State
-	Width           int
-	Height          int
-	Spinner         spinner.Model
-	LoadingType     string
-	CurrentQuery    string
-	Videos          []list.Item
-	VideoList       models.VideoListModel
-	FormatList      models.FormatListModel
-	Download        models.DownloadModel
-	SelectedVideo   types.VideoItem
-	ErrMsg          string
-	SearchManager   *utils.SearchManager
-	FormatsManager  *utils.FormatsManager
-	DownloadManager *utils.DownloadManager
+	Program           *tea.Program
+	Search            models.SearchModel
+	State             types.State
+	Width             int
+	Height            int
+	Spinner           spinner.Model
+	LoadingType       string
+	CurrentQuery      string
+	Videos            []list.Item
+	VideoList         models.VideoListModel
+	FormatList        models.FormatListModel
+	Download          models.DownloadModel
+	Queue             models.QueueModel
+	Library           models.LibraryModel
+	History           models.HistoryBrowserModel
+	Recent            models.RecentModel
+	ReturnState       types.State
+	QueuePrevState    types.State
+	FormatReturnState types.State
+	SelectedVideo     types.VideoItem
+	ErrMsg            string
+	SearchManager     *utils.SearchManager
+	MoreManager       *utils.SearchManager
+	FormatsManager    *utils.FormatsManager
+	DownloadQueue     *utils.DownloadQueue
+	AutoPickPreset    string
+	Duplicate         *duplicatePrompt
+	Notice            string
+	ResumePrompt      int
+}
+
+type duplicatePrompt struct {
+	Msg   types.StartFormatMsg
+	Entry utils.LibraryEntry
 }
 
 func (m *Model) Init() tea.Cmd {
-	m.InitDownloadManager()
+	m.InitDownloadQueue()
+	m.SearchManager.SetProgram(m.Program)
+	m.MoreManager.SetProgram(m.Program)
 	opts := m.Search.Options
 	var cmd tea.Cmd
 
@@ -45,8 +63,9 @@ func (m *Model) Init() tea.Cmd {
 			m.VideoList.IsChannelSearch = true
 			m.VideoList.IsPlaylistSearch = false
 			m.VideoList.ChannelName = opts.Channel
+			m.VideoList.ChannelTab = opts.ChannelTab
 			m.VideoList.PlaylistURL = ""
-			cmd = utils.PerformChannelSearch(m.SearchManager, opts.Channel, m.Search.SearchLimit)
+			cmd = utils.PerformChannelSearch(m.SearchManager, opts.Channel, opts.ChannelTab, m.Search.SearchLimit)
 		}
 
 		if opts.Query != "" {
@@ -58,7 +77,7 @@ func (m *Model) Init() tea.Cmd {
 			m.VideoList.ChannelName = ""
 			m.VideoList.PlaylistName = ""
 			m.VideoList.PlaylistURL = ""
-			cmd = utils.PerformSearch(m.SearchManager, opts.Query, m.Search.SortBy.GetSPParam(), m.Search.SearchLimit)
+			cmd = utils.PerformSearch(m.SearchManager, opts.Query, types.GetSPParam(m.Search.SortBy, m.Search.Filters), m.Search.SearchLimit)
 		}
 
 		if opts.Playlist != "" {
@@ -69,32 +88,59 @@ func (m *Model) Init() tea.Cmd {
 			m.VideoList.IsChannelSearch = false
 			m.VideoList.PlaylistName = opts.Playlist
 
-			if strings.Contains(opts.Playlist, "https://www.youtube.com/playlist?list=") {
-				m.VideoList.PlaylistURL = opts.Playlist
-			} else if strings.Contains(opts.Playlist, "watch?v=") && strings.Contains(opts.Playlist, "list=") {
-				parts := strings.Split(opts.Playlist, "list=")
-				if len(parts) > 1 {
-					playlistID := parts[1]
-					if idx := strings.Index(playlistID, "&"); idx != -1 {
-						playlistID = playlistID[:idx]
-					}
-					m.VideoList.PlaylistURL = "https://www.youtube.com/playlist?list=" + playlistID
-				} else {
-					m.VideoList.PlaylistURL = "https://www.youtube.com/playlist?list=" + opts.Playlist
-				}
-			} else {
-				m.VideoList.PlaylistURL = "https://www.youtube.com/playlist?list=" + opts.Playlist
-			}
+			m.VideoList.PlaylistURL = utils.PlaylistURL(opts.Playlist)
 
 			cmd = utils.PerformPlaylistSearch(m.SearchManager, m.VideoList.PlaylistURL, m.Search.SearchLimit)
 		}
 	}
 
-	return tea.Batch(m.Search.Init(), m.Spinner.Tick, m.Download.Init(), cmd)
+	if m.State == types.StateSearchInput {
+		if downloads, err := utils.LoadUnfinished(); err == nil {
+			m.ResumePrompt = len(downloads)
+		}
+	}
+
+	var syncCmd tea.Cmd
+	if cfg, err := config.Load(); err == nil && cfg.SyncOnStartup && len(cfg.AutoDownloadRules) > 0 {
+		syncCmd = func() tea.Msg {
+			return types.StartSyncMsg{}
+		}
+	}
+
+	return tea.Batch(m.Search.Init(), m.Spinner.Tick, m.Download.Init(), cmd, syncCmd)
+}
+
+func (m *Model) InitDownloadQueue() {
+	m.DownloadQueue.SetProgram(m.Program)
+	m.Download.DownloadQueue = m.DownloadQueue
+	m.Queue.DownloadQueue = m.DownloadQueue
 }
 
-func (m *Model) InitDownloadManager() {
-	m.Download.DownloadManager = m.DownloadManager
+func (m *Model) autoPickPreset() (config.FormatPreset, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	name := m.AutoPickPreset
+	if name == "" {
+		name = cfg.AutoPickPreset
+	}
+
+	if name == "" {
+		return config.FormatPreset{}, false
+	}
+
+	return cfg.FindPreset(name)
+}
+
+func newDownloadQueue() *utils.DownloadQueue {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	return utils.NewDownloadQueue(cfg.MaxConcurrentDownloads)
 }
 
 func NewModel() *Model {
@@ -103,15 +149,20 @@ func NewModel() *Model {
 	sp.Style = sp.Style.Foreground(styles.PinkColor)
 
 	return &Model{
-		State:           types.StateSearchInput,
-		Spinner:         sp,
-		Search:          models.NewSearchModel(),
-		VideoList:       models.NewVideoListModel(),
-		FormatList:      models.NewFormatListModel(),
-		Download:        models.NewDownloadModel(),
-		SearchManager:   utils.NewSearchManager(),
-		FormatsManager:  utils.NewFormatsManager(),
-		DownloadManager: utils.NewDownloadManager(),
+		State:          types.StateSearchInput,
+		Spinner:        sp,
+		Search:         models.NewSearchModel(),
+		VideoList:      models.NewVideoListModel(),
+		FormatList:     models.NewFormatListModel(),
+		Download:       models.NewDownloadModel(),
+		Queue:          models.NewQueueModel(),
+		Library:        models.NewLibraryModel(),
+		History:        models.NewHistoryBrowserModel(),
+		Recent:         models.NewRecentModel(),
+		SearchManager:  utils.NewSearchManager(),
+		MoreManager:    utils.NewSearchManager(),
+		FormatsManager: utils.NewFormatsManager(),
+		DownloadQueue:  newDownloadQueue(),
 	}
 }
 
@@ -121,14 +172,20 @@ func NewModelWithOptions(opts *models.CLIOptions) *Model {
 	sp.Style = sp.Style.Foreground(styles.PinkColor)
 
 	return &Model{
-		State:           types.StateSearchInput,
-		Spinner:         sp,
-		Search:          models.NewSearchModelWithOptions(opts),
-		VideoList:       models.NewVideoListModel(),
-		FormatList:      models.NewFormatListModel(),
-		Download:        models.NewDownloadModel(),
-		SearchManager:   utils.NewSearchManager(),
-		FormatsManager:  utils.NewFormatsManager(),
-		DownloadManager: utils.NewDownloadManager(),
+		State:          types.StateSearchInput,
+		Spinner:        sp,
+		Search:         models.NewSearchModelWithOptions(opts),
+		VideoList:      models.NewVideoListModel(),
+		FormatList:     models.NewFormatListModel(),
+		Download:       models.NewDownloadModel(),
+		Queue:          models.NewQueueModel(),
+		Library:        models.NewLibraryModel(),
+		History:        models.NewHistoryBrowserModel(),
+		Recent:         models.NewRecentModel(),
+		SearchManager:  utils.NewSearchManager(),
+		MoreManager:    utils.NewSearchManager(),
+		FormatsManager: utils.NewFormatsManager(),
+		DownloadQueue:  newDownloadQueue(),
+		AutoPickPreset: opts.Preset,
 	}
 }
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"fmt"
+	"log"
 	"strings"
 
 	"github.com/xdagiz/xytz/internal/models"
@@ -23,6 +25,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.VideoList = m.VideoList.HandleResize(m.Width, m.Height)
 		m.FormatList = m.FormatList.HandleResize(m.Width, m.Height)
 		m.Download = m.Download.HandleResize(m.Width, m.Height)
+		m.Queue = m.Queue.HandleResize(m.Width, m.Height)
+		m.Library = m.Library.HandleResize(m.Width, m.Height)
+		m.History = m.History.HandleResize(m.Width, m.Height)
+		m.Recent = m.Recent.HandleResize(m.Width, m.Height)
 
 	case spinner.TickMsg:
 		var spinnerCmd tea.Cmd
@@ -35,14 +41,45 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.CurrentQuery = strings.TrimSpace(msg.Query)
 		m.VideoList.IsChannelSearch = false
 		m.VideoList.IsPlaylistSearch = false
+		m.VideoList.IsFeed = false
 		m.VideoList.ChannelName = ""
 		m.VideoList.PlaylistName = ""
 		m.VideoList.PlaylistURL = ""
-		cmd = utils.PerformSearch(m.SearchManager, msg.Query, m.Search.SortBy.GetSPParam(), m.Search.SearchLimit)
+		cmd = utils.PerformSearch(m.SearchManager, msg.Query, types.GetSPParam(m.Search.SortBy, m.Search.Filters), m.Search.SearchLimit)
 		m.ErrMsg = ""
 		m.Search.Input.SetValue("")
 
 	case types.StartFormatMsg:
+		if !msg.IgnoreLibrary {
+			videoID := msg.SelectedVideo.ID
+			if videoID == "" {
+				videoID = utils.ExtractVideoID(msg.URL)
+			}
+			if found := utils.FindInLibrary(videoID); len(found) > 0 {
+				m.Duplicate = &duplicatePrompt{Msg: msg, Entry: found[0]}
+				return m, nil
+			}
+		}
+
+		m.FormatReturnState = ""
+		if m.State == types.StateRecent {
+			m.FormatReturnState = types.StateRecent
+		}
+
+		if preset, ok := m.autoPickPreset(); ok && !msg.ChooseFormat {
+			m.SelectedVideo = msg.SelectedVideo
+			m.FormatList.SelectedVideo = msg.SelectedVideo
+			m.ErrMsg = ""
+			return m, func() tea.Msg {
+				return types.StartDownloadMsg{
+					URL:         msg.URL,
+					FormatID:    preset.Format,
+					IsAudioTab:  preset.IsAudio(),
+					AudioFormat: preset.AudioFormat,
+				}
+			}
+		}
+
 		m.State = types.StateLoading
 		m.LoadingType = "format"
 		m.FormatList.URL = msg.URL
@@ -54,72 +91,194 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ErrMsg = ""
 
 	case types.SearchResultMsg:
+		if msg.Start > 1 {
+			if msg.URL != m.VideoList.SearchURL || msg.Start != m.VideoList.NextStart {
+				return m, nil
+			}
+			if msg.Err != "" {
+				m.VideoList.LoadingMore = false
+				m.ErrMsg = msg.Err
+				return m, nil
+			}
+			cmd = m.VideoList.AppendVideos(msg.Videos)
+			m.VideoList.SetPage(msg.URL, msg.Next)
+			m.Videos = m.VideoList.List.Items()
+			return m, tea.Batch(cmd, m.VideoList.LoadMore())
+		}
+
+		if m.VideoList.Streaming {
+			// The results were already streamed in, so keep the cursor and
+			// selection and only add what was missed.
+			if msg.URL != m.VideoList.SearchURL {
+				return m, nil
+			}
+			cmd = m.VideoList.AppendVideos(msg.Videos)
+			m.VideoList.SetPage(msg.URL, msg.Next)
+			m.Videos = m.VideoList.List.Items()
+			m.Search.History.SetResultCount(len(m.Videos))
+			return m, tea.Batch(cmd, m.VideoList.LoadMore())
+		}
+
+		// Drop what a stopped stream finished with after the user moved on.
+		if msg.URL != "" && !m.loadingResults() {
+			return m, nil
+		}
+
 		m.LoadingType = ""
+		m.cancelLoadMore()
+		m.Search.History.SetResultCount(len(msg.Videos))
 		m.Videos = msg.Videos
-		m.VideoList.List.SetItems(msg.Videos)
+		m.VideoList.SetVideos(msg.Videos)
+		m.VideoList.SetPage(msg.URL, msg.Next)
 		m.VideoList.CurrentQuery = m.CurrentQuery
 		m.VideoList.ErrMsg = msg.Err
 		m.State = types.StateVideoList
 		m.ErrMsg = msg.Err
-		return m, nil
+		return m, m.VideoList.LoadMore()
+
+	case types.SearchProgressMsg:
+		if msg.Start == 1 {
+			if !m.VideoList.Streaming {
+				if !m.loadingResults() {
+					return m, nil
+				}
+				m.VideoList.StartStreaming(msg.URL)
+				m.VideoList.CurrentQuery = m.CurrentQuery
+				m.VideoList.ErrMsg = ""
+				m.State = types.StateVideoList
+				m.LoadingType = ""
+				m.ErrMsg = ""
+			} else if msg.URL != m.VideoList.SearchURL {
+				return m, nil
+			}
+		} else if !m.VideoList.LoadingMore || msg.URL != m.VideoList.SearchURL || msg.Start != m.VideoList.NextStart {
+			return m, nil
+		}
+
+		cmd = m.VideoList.AddStreamed(msg.Item, msg.Index)
+		m.Videos = m.VideoList.List.Items()
+		return m, cmd
+
+	case types.LoadMoreMsg:
+		if !m.VideoList.HasMore() {
+			m.VideoList.LoadingMore = false
+			return m, nil
+		}
+		return m, utils.LoadMoreResults(m.MoreManager, m.VideoList.SearchURL, m.VideoList.NextStart, m.Search.SearchLimit)
 	case types.FormatResultMsg:
 		m.LoadingType = ""
 		m.FormatList.SetFormats(msg.VideoFormats, msg.AudioFormats, msg.ThumbnailFormats, msg.AllFormats)
 		if msg.VideoInfo.ID != "" {
 			m.FormatList.SelectedVideo = msg.VideoInfo
 		}
+		if msg.Err == "" {
+			if err := utils.AddRecent(utils.RecentOpened, m.FormatList.SelectedVideo); err != nil {
+				log.Printf("Failed to add video to recent videos: %v", err)
+			}
+		}
 		m.State = types.StateFormatList
 		m.ErrMsg = msg.Err
 		return m, nil
 
 	case types.StartDownloadMsg:
-		m.State = types.StateDownload
-		m.Download.Completed = false
-		m.Download.Cancelled = false
-		if m.SelectedVideo.ID == "" {
-			m.Download.SelectedVideo = m.FormatList.SelectedVideo
-		} else {
-			m.Download.SelectedVideo = m.SelectedVideo
+		video := m.SelectedVideo
+		if video.ID == "" {
+			video = m.FormatList.SelectedVideo
 		}
-		m.LoadingType = "download"
 		req := types.DownloadRequest{
 			URL:                msg.URL,
 			FormatID:           msg.FormatID,
 			IsAudioTab:         msg.IsAudioTab,
 			ABR:                msg.ABR,
+			AudioFormat:        msg.AudioFormat,
+			Title:              video.Title(),
 			Options:            m.Search.DownloadOptions,
 			CookiesFromBrowser: m.Search.CookiesFromBrowser,
 			Cookies:            m.Search.Cookies,
 		}
-		cmd = utils.StartDownload(m.DownloadManager, m.Program, m.SelectedVideo.Title(), req)
-		return m, cmd
-	case types.StartResumeDownloadMsg:
-		m.State = types.StateDownload
-		m.Download.Completed = false
-		m.Download.Cancelled = false
-		m.Download.SelectedVideo = types.VideoItem{VideoTitle: msg.Title}
-		m.LoadingType = "download"
+		return m, m.trackDownload(m.DownloadQueue.Enqueue(video, req), video)
+
+	case types.StartBatchDownloadMsg:
+		for _, video := range msg.Videos {
+			req := types.DownloadRequest{
+				URL:                "https://www.youtube.com/watch?v=" + video.ID,
+				FormatID:           msg.FormatID,
+				IsAudioTab:         msg.IsAudio,
+				Title:              video.Title(),
+				Options:            m.Search.DownloadOptions,
+				CookiesFromBrowser: m.Search.CookiesFromBrowser,
+				Cookies:            m.Search.Cookies,
+			}
+			m.DownloadQueue.Enqueue(video, req)
+		}
+		m.showQueue()
+		return m, nil
+
+	case types.StartCollectionDownloadMsg:
+		video := types.VideoItem{VideoTitle: msg.Title}
 		req := types.DownloadRequest{
 			URL:                msg.URL,
 			FormatID:           msg.FormatID,
-			IsAudioTab:         false,
-			ABR:                0,
+			IsAudioTab:         msg.IsAudio,
+			Title:              msg.Title,
 			Options:            m.Search.DownloadOptions,
 			CookiesFromBrowser: m.Search.CookiesFromBrowser,
 			Cookies:            m.Search.Cookies,
+			Collection:         true,
+			PlaylistItems:      msg.PlaylistItems,
+			DateAfter:          msg.DateAfter,
+			DateBefore:         msg.DateBefore,
+		}
+		return m, m.trackDownload(m.DownloadQueue.Enqueue(video, req), video)
+
+	case types.StartResumeDownloadMsg:
+		req := m.resumeRequest(msg.Request)
+		video := types.VideoItem{VideoTitle: req.Title}
+		return m, m.trackDownload(m.DownloadQueue.Enqueue(video, req), video)
+
+	case types.ResumeAllMsg:
+		downloads, err := utils.LoadUnfinished()
+		if err != nil {
+			m.ErrMsg = "Failed to load unfinished downloads: " + err.Error()
+			return m, nil
 		}
-		cmd = utils.StartDownload(m.DownloadManager, m.Program, msg.Title, req)
+		for _, d := range downloads {
+			req := m.resumeRequest(d.DownloadRequest())
+			m.DownloadQueue.Enqueue(types.VideoItem{VideoTitle: req.Title}, req)
+		}
+		m.showQueue()
+		return m, nil
+
+	case types.ProgressMsg:
+		m.Download, cmd = m.Download.Update(msg)
 		return m, cmd
 
 	case types.DownloadResultMsg:
+		if msg.Err == "" {
+			m.VideoList.ReloadArchive()
+		}
+
+		if msg.JobID != m.Download.JobID {
+			if msg.Err != "" {
+				if job, ok := m.DownloadQueue.Job(msg.JobID); ok && job.State == types.JobFailed {
+					m.ErrMsg = job.Title() + ": " + msg.Err
+				}
+			}
+			return m, nil
+		}
+
 		m.LoadingType = ""
 		if msg.Err != "" {
 			if !m.Download.Cancelled {
 				m.ErrMsg = msg.Err
-				m.State = types.StateSearchInput
+				if m.State == types.StateDownload {
+					m.State = types.StateSearchInput
+				}
 			}
 		} else {
 			m.Download.Completed = true
+			m.Download.FilePath = msg.FilePath
+			m.Download.FilePaths = msg.FilePaths
 		}
 		return m, nil
 
@@ -133,25 +292,69 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case types.PauseDownloadMsg:
-		m.Download.Paused = true
+		if msg.JobID == m.Download.JobID {
+			m.Download.Paused = true
+		}
 		return m, nil
 
 	case types.ResumeDownloadMsg:
-		m.Download.Paused = false
+		if msg.JobID == m.Download.JobID {
+			m.Download.Paused = false
+		}
 		return m, nil
 
 	case types.CancelDownloadMsg:
-		m.Download.Cancelled = true
-		if m.SelectedVideo.ID == "" {
-			m.State = types.StateSearchInput
-		} else {
-			m.State = types.StateVideoList
+		if msg.JobID == m.Download.JobID {
+			m.Download.Cancelled = true
 		}
-		m.ErrMsg = "Download cancelled"
-		m.FormatList.List.ResetSelected()
-		cmd = utils.CancelDownload(m.DownloadManager)
+		if m.State == types.StateDownload && msg.JobID == m.Download.JobID {
+			if m.ReturnState != "" {
+				m.State = m.ReturnState
+			} else if m.FormatReturnState == types.StateRecent {
+				m.State = types.StateRecent
+				m.Recent.Reload()
+			} else if m.SelectedVideo.ID == "" {
+				m.State = types.StateSearchInput
+			} else {
+				m.State = types.StateVideoList
+			}
+			m.ErrMsg = "Download cancelled"
+			m.FormatList.List.ResetSelected()
+		}
+		cmd = utils.CancelDownload(m.DownloadQueue, msg.JobID)
 		return m, cmd
 
+	case types.ShowQueueMsg:
+		m.showQueue()
+		return m, nil
+
+	case types.OpenDownloadMsg:
+		job, ok := m.DownloadQueue.Job(msg.JobID)
+		if !ok {
+			return m, nil
+		}
+		if job.State == types.JobFailed {
+			m.ErrMsg = job.Err
+			return m, nil
+		}
+		cmd = m.trackDownload(job.ID, job.Video)
+		m.ReturnState = types.StateQueue
+		m.Download.Completed = job.State == types.JobDone
+		m.Download.Cancelled = job.State == types.JobCancelled
+		m.Download.Paused = job.State == types.JobPaused
+		m.Download.CurrentSpeed = job.Speed
+		m.Download.CurrentETA = job.Eta
+		m.Download.Status = job.Status
+		m.Download.Phase = job.Phase
+		m.Download.ItemIndex = job.ItemIndex
+		m.Download.ItemCount = job.ItemCount
+		m.Download.FilePaths = job.FilePaths
+		if len(job.FilePaths) > 0 {
+			m.Download.FilePath = job.FilePaths[len(job.FilePaths)-1]
+		}
+		m.Download.FileDestination = job.Destination
+		return m, tea.Batch(cmd, m.Download.Progress.SetPercent(job.Percent/100.0))
+
 	case types.CancelSearchMsg:
 		m.State = types.StateSearchInput
 		m.LoadingType = ""
@@ -170,9 +373,11 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.LoadingType = "channel"
 		m.VideoList.IsChannelSearch = true
 		m.VideoList.IsPlaylistSearch = false
+		m.VideoList.IsFeed = false
 		m.VideoList.ChannelName = msg.ChannelName
+		m.VideoList.ChannelTab = msg.Tab
 		m.VideoList.PlaylistURL = ""
-		cmd = utils.PerformChannelSearch(m.SearchManager, msg.ChannelName, m.Search.SearchLimit)
+		cmd = utils.PerformChannelSearch(m.SearchManager, msg.ChannelName, msg.Tab, m.Search.SearchLimit)
 		m.ErrMsg = ""
 		return m, cmd
 
@@ -182,28 +387,88 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.CurrentQuery = strings.TrimSpace(msg.Query)
 		m.VideoList.IsPlaylistSearch = true
 		m.VideoList.IsChannelSearch = false
+		m.VideoList.IsFeed = false
 		m.VideoList.PlaylistName = strings.TrimSpace(msg.Query)
-		if strings.Contains(msg.Query, "https://www.youtube.com/playlist?list=") {
-			m.VideoList.PlaylistURL = msg.Query
-		} else if strings.Contains(msg.Query, "watch?v=") && strings.Contains(msg.Query, "list=") {
-			parts := strings.Split(msg.Query, "list=")
-			if len(parts) > 1 {
-				playlistID := parts[1]
-				if idx := strings.Index(playlistID, "&"); idx != -1 {
-					playlistID = playlistID[:idx]
-				}
-				m.VideoList.PlaylistURL = "https://www.youtube.com/playlist?list=" + playlistID
-			} else {
-				m.VideoList.PlaylistURL = "https://www.youtube.com/playlist?list=" + msg.Query
-			}
-		} else {
-			m.VideoList.PlaylistURL = "https://www.youtube.com/playlist?list=" + msg.Query
+		if msg.Title != "" {
+			m.VideoList.PlaylistName = msg.Title
 		}
+		m.VideoList.PlaylistURL = utils.PlaylistURL(msg.Query)
 		cmd = utils.PerformPlaylistSearch(m.SearchManager, msg.Query, m.Search.SearchLimit)
 		m.ErrMsg = ""
 		return m, cmd
 
+	case types.StartFeedMsg:
+		m.State = types.StateLoading
+		m.LoadingType = "feed"
+		m.VideoList.IsFeed = true
+		m.VideoList.IsChannelSearch = false
+		m.VideoList.IsPlaylistSearch = false
+		m.VideoList.ChannelName = ""
+		m.VideoList.PlaylistURL = ""
+		cmd = utils.PerformFeedSearch(m.SearchManager, m.Search.SearchLimit)
+		m.ErrMsg = ""
+		return m, cmd
+
+	case types.FeedResultMsg:
+		m.LoadingType = ""
+		if len(msg.Videos) == 0 {
+			m.State = types.StateSearchInput
+			m.ErrMsg = msg.Err
+			return m, nil
+		}
+		m.Videos = msg.Videos
+		m.VideoList.SetVideos(msg.Videos)
+		m.VideoList.SetNew(msg.NewIDs)
+		m.VideoList.ErrMsg = ""
+		m.State = types.StateVideoList
+		m.ErrMsg = ""
+		return m, nil
+
+	case types.StartSyncMsg:
+		return m, utils.PerformSync()
+
+	case types.SyncResultMsg:
+		for _, req := range msg.Requests {
+			m.DownloadQueue.Enqueue(types.VideoItem{VideoTitle: req.Title}, req)
+		}
+
+		switch {
+		case len(msg.Errs) > 0:
+			m.ErrMsg = "Auto-download: " + msg.Errs[0].Error()
+		case msg.NoRules:
+			m.ErrMsg = "No auto_download_rules configured"
+		default:
+			m.Notice = fmt.Sprintf("Auto-download: queued %d downloads from %d channels", len(msg.Requests), msg.Channels)
+		}
+		return m, nil
+
+	case types.SubscribeMsg:
+		label := "@" + utils.ExtractChannelUsername(msg.Channel)
+		var (
+			changed bool
+			err     error
+		)
+		if msg.Unsubscribe {
+			changed, err = utils.Unsubscribe(msg.Channel)
+		} else {
+			changed, err = utils.Subscribe(msg.Channel)
+		}
+		switch {
+		case err != nil:
+			m.ErrMsg = "Failed to update subscriptions: " + err.Error()
+		case msg.Unsubscribe && changed:
+			m.Notice = "Unsubscribed from " + label
+		case msg.Unsubscribe:
+			m.ErrMsg = "Not subscribed to " + label
+		case changed:
+			m.Notice = "Subscribed to " + label
+		default:
+			m.Notice = "Already subscribed to " + label
+		}
+		return m, nil
+
 	case types.BackFromVideoListMsg:
+		m.cancelLoadMore()
 		m.State = types.StateSearchInput
 		m.ErrMsg = ""
 		m.SelectedVideo = types.VideoItem{}
@@ -212,10 +477,68 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.VideoList.PlaylistURL = ""
 		return m, nil
 
+	case types.ShowHistoryMsg:
+		m.History.Reload()
+		m.History.Notice = ""
+		m.State = types.StateHistory
+		m.ErrMsg = ""
+		return m, nil
+
+	case types.RunHistoryMsg:
+		m.State = types.StateSearchInput
+		if msg.SortBy != "" {
+			m.Search.SortBy = types.SortBy(msg.SortBy)
+		}
+		m.Search.Filters = msg.Filters
+		m.Search, cmd = m.Search.Submit(msg.Query)
+		return m, cmd
+
+	case types.ShowRecentMsg:
+		m.Recent.Reload()
+		m.Recent.Notice = ""
+		m.State = types.StateRecent
+		m.ErrMsg = ""
+		return m, nil
+
+	case types.ShowLibraryMsg:
+		m.Library.Reload()
+		m.State = types.StateLibrary
+		m.ErrMsg = ""
+		return m, nil
+
 	case tea.KeyMsg:
+		m.Notice = ""
+		if m.ResumePrompt > 0 && msg.Type != tea.KeyCtrlC {
+			m.ResumePrompt = 0
+			switch msg.String() {
+			case "y":
+				return m, func() tea.Msg { return types.ResumeAllMsg{} }
+			case "n", "esc":
+				return m, nil
+			}
+		}
+
+		if m.Duplicate != nil && msg.Type != tea.KeyCtrlC {
+			prompt := m.Duplicate
+			m.Duplicate = nil
+			if msg.String() == "y" {
+				next := prompt.Msg
+				next.IgnoreLibrary = true
+				return m, func() tea.Msg { return next }
+			}
+			return m, nil
+		}
+
 		switch msg.Type {
 		case tea.KeyCtrlC:
 			return m, tea.Quit
+		case tea.KeyCtrlT:
+			if m.State == types.StateQueue {
+				m.hideQueue()
+			} else if m.State != types.StateLoading {
+				m.showQueue()
+			}
+			return m, nil
 		}
 
 		switch m.State {
@@ -234,9 +557,20 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case types.StateVideoList:
+			if m.VideoList.HasPrompt() {
+				m.VideoList, cmd = m.VideoList.Update(msg)
+				return m, cmd
+			}
+
 			switch msg.String() {
+			case "c":
+				if m.VideoList.Streaming && m.VideoList.List.FilterState() != list.Filtering {
+					m.stopStreaming()
+					return m, nil
+				}
 			case "b", "esc":
 				if m.VideoList.List.FilterState() == list.Unfiltered {
+					m.cancelLoadMore()
 					m.State = types.StateSearchInput
 					m.ErrMsg = ""
 					m.Search.Input.SetValue("")
@@ -253,7 +587,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "b", "esc":
 				if m.FormatList.ActiveTab != models.FormatTabCustom {
 					if m.FormatList.List.FilterState() == list.Unfiltered {
-						if m.SelectedVideo.ID == "" {
+						if m.FormatReturnState == types.StateRecent {
+							m.State = types.StateRecent
+							m.Recent.Reload()
+						} else if m.SelectedVideo.ID == "" {
 							m.State = types.StateSearchInput
 							m.Search.Input.SetValue("")
 							m.FormatList.List.ResetFilter()
@@ -271,13 +608,72 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case types.StateDownload:
 			switch msg.String() {
 			case "b":
-				if m.Download.Completed || m.Download.Cancelled {
+				if m.ReturnState != "" {
+					m.State = m.ReturnState
+					m.ReturnState = ""
+				} else if m.Download.SelectedVideo.ID == "" {
+					m.State = types.StateSearchInput
+				} else {
 					m.State = types.StateFormatList
 					m.FormatList.List.ResetSelected()
 				}
 				m.ErrMsg = ""
 				return m, nil
 			}
+
+		case types.StateQueue:
+			switch msg.String() {
+			case "b", "esc":
+				m.hideQueue()
+				return m, nil
+			case "q":
+				return m, tea.Quit
+			}
+			m.Queue, cmd = m.Queue.Update(msg)
+
+		case types.StateHistory:
+			if !m.History.IsCapturingKeys() {
+				switch msg.String() {
+				case "b", "esc":
+					if m.History.List.FilterState() == list.Unfiltered {
+						m.State = types.StateSearchInput
+						m.Search.History.Load()
+						return m, nil
+					}
+				case "q":
+					return m, tea.Quit
+				}
+			}
+			m.History, cmd = m.History.Update(msg)
+
+		case types.StateRecent:
+			if !m.Recent.IsCapturingKeys() {
+				switch msg.String() {
+				case "b", "esc":
+					if m.Recent.List.FilterState() == list.Unfiltered {
+						m.State = types.StateSearchInput
+						return m, nil
+					}
+				case "q":
+					return m, tea.Quit
+				}
+			}
+			m.Recent, cmd = m.Recent.Update(msg)
+
+		case types.StateLibrary:
+			if !m.Library.IsCapturingKeys() {
+				switch msg.String() {
+				case "b", "esc":
+					if m.Library.List.FilterState() == list.Unfiltered {
+						m.State = types.StateSearchInput
+						m.Library.Notice = ""
+						return m, nil
+					}
+				case "q":
+					return m, tea.Quit
+				}
+			}
+			m.Library, cmd = m.Library.Update(msg)
 		}
 
 	case tea.MouseMsg:
@@ -306,3 +702,78 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return m, cmd
 }
+
+func (m *Model) trackDownload(jobID int, video types.VideoItem) tea.Cmd {
+	m.State = types.StateDownload
+	m.ReturnState = ""
+	m.LoadingType = "download"
+	m.ErrMsg = ""
+	return m.Download.Track(jobID, video)
+}
+
+func (m *Model) loadingResults() bool {
+	if m.State != types.StateLoading {
+		return false
+	}
+
+	switch m.LoadingType {
+	case "search", "channel", "playlist":
+		return true
+	}
+
+	return false
+}
+
+func (m *Model) cancelLoadMore() {
+	if m.VideoList.Streaming {
+		if err := m.SearchManager.Cancel(); err != nil {
+			log.Printf("Failed to cancel search: %v", err)
+		}
+	}
+	if m.VideoList.LoadingMore {
+		if err := m.MoreManager.Cancel(); err != nil {
+			log.Printf("Failed to cancel loading more results: %v", err)
+		}
+	}
+	m.VideoList.SetPage("", 0)
+}
+
+func (m *Model) stopStreaming() {
+	if err := m.SearchManager.Cancel(); err != nil {
+		log.Printf("Failed to cancel search: %v", err)
+	}
+
+	m.VideoList.SetPage(m.VideoList.SearchURL, m.VideoList.LastIndex+1)
+	m.Videos = m.VideoList.List.Items()
+	m.Search.History.SetResultCount(len(m.Videos))
+	m.Notice = fmt.Sprintf("Stopped loading after %d results", len(m.Videos))
+}
+
+func (m *Model) showQueue() {
+	if m.State != types.StateQueue {
+		m.QueuePrevState = m.State
+	}
+	m.State = types.StateQueue
+	m.ErrMsg = ""
+}
+
+func (m *Model) hideQueue() {
+	m.State = m.QueuePrevState
+	if m.State == "" || m.State == types.StateQueue {
+		m.State = types.StateSearchInput
+	}
+	m.ErrMsg = ""
+}
+
+func (m *Model) resumeRequest(req types.DownloadRequest) types.DownloadRequest {
+	if req.Options == nil {
+		req.Options = m.Search.DownloadOptions
+	}
+
+	if req.CookiesFromBrowser == "" && req.Cookies == "" {
+		req.CookiesFromBrowser = m.Search.CookiesFromBrowser
+		req.Cookies = m.Search.Cookies
+	}
+
+	return req
+}
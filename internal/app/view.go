@@ -14,13 +14,16 @@ import (
 )
 
 type StatusBarConfig struct {
-	HasError      bool
-	HelpVisible   bool
-	IsPaused      bool
-	IsCompleted   bool
-	IsCancelled   bool
-	Keys          models.StatusKeys
-	ResumeVisible bool
+	HasError       bool
+	HelpVisible    bool
+	IsPaused       bool
+	IsCompleted    bool
+	IsCancelled    bool
+	Keys           models.StatusKeys
+	ResumeVisible  bool
+	BatchPrompt    bool
+	Collection     bool
+	CanDownloadAll bool
 }
 
 func getStatusBarText(state types.State, cfg StatusBarConfig, helpKeys models.HelpKeys) string {
@@ -47,6 +50,7 @@ func getStatusBarText(state types.State, cfg StatusBarConfig, helpKeys models.He
 					Select: cfg.Keys.Select,
 					Delete: cfg.Keys.Delete,
 					Cancel: cfg.Keys.Cancel,
+					All:    cfg.Keys.All,
 				}),
 			)
 		}
@@ -67,16 +71,61 @@ func getStatusBarText(state types.State, cfg StatusBarConfig, helpKeys models.He
 				Enter: cfg.Keys.Enter,
 			})
 		}
-		return models.FormatKeysForStatusBar(models.StatusKeys{
-			Quit: cfg.Keys.Quit,
-			Back: cfg.Keys.Back,
-		})
+		if cfg.Collection {
+			return models.FormatKeysForStatusBar(models.StatusKeys{
+				Quit: cfg.Keys.Quit,
+				Enter: key.NewBinding(
+					key.WithKeys("enter"),
+					key.WithHelp("Enter", "download"),
+				),
+				Cancel: key.NewBinding(
+					key.WithKeys("esc"),
+					key.WithHelp("Esc", "cancel"),
+				),
+				Tab: key.NewBinding(
+					key.WithKeys("tab", "shift+tab"),
+					key.WithHelp("Tab/Shift+tab", "next/prev field"),
+				),
+			})
+		}
+		if cfg.BatchPrompt {
+			return models.FormatKeysForStatusBar(models.StatusKeys{
+				Quit: cfg.Keys.Quit,
+				Enter: key.NewBinding(
+					key.WithKeys("enter"),
+					key.WithHelp("Enter", "download"),
+				),
+				Cancel: key.NewBinding(
+					key.WithKeys("esc"),
+					key.WithHelp("Esc", "cancel"),
+				),
+				Tab: key.NewBinding(
+					key.WithKeys("tab"),
+					key.WithHelp("Tab", "video/audio"),
+				),
+			})
+		}
+		keys := models.StatusKeys{
+			Quit:      cfg.Keys.Quit,
+			Back:      cfg.Keys.Back,
+			Tab:       cfg.Keys.Tab,
+			Select:    cfg.Keys.Select,
+			Batch:     cfg.Keys.Batch,
+			Downloads: cfg.Keys.Downloads,
+		}
+		if cfg.CanDownloadAll {
+			keys.All = cfg.Keys.All
+		}
+		return models.FormatKeysForStatusBar(keys)
 	case types.StateFormatList:
 		return models.FormatKeysForStatusBar(models.StatusKeys{
-			Quit: cfg.Keys.Quit,
-			Back: cfg.Keys.Back,
-			Tab:  cfg.Keys.Tab,
+			Quit:      cfg.Keys.Quit,
+			Back:      cfg.Keys.Back,
+			Tab:       cfg.Keys.Tab,
+			Downloads: cfg.Keys.Downloads,
 		})
+	case types.StateQueue, types.StateLibrary, types.StateHistory, types.StateRecent:
+		return models.FormatKeysForStatusBar(cfg.Keys)
 	case types.StateDownload:
 		if cfg.IsCompleted || cfg.IsCancelled {
 			return models.FormatKeysForStatusBar(models.StatusKeys{
@@ -88,12 +137,14 @@ func getStatusBarText(state types.State, cfg StatusBarConfig, helpKeys models.He
 		if cfg.IsPaused {
 			return models.FormatKeysForStatusBar(models.StatusKeys{
 				Quit:   cfg.Keys.Quit,
+				Back:   cfg.Keys.Back,
 				Pause:  cfg.Keys.Pause,
 				Cancel: cfg.Keys.Cancel,
 			})
 		}
 		return models.FormatKeysForStatusBar(models.StatusKeys{
 			Quit:   cfg.Keys.Quit,
+			Back:   cfg.Keys.Back,
 			Pause:  cfg.Keys.Pause,
 			Cancel: cfg.Keys.Cancel,
 		})
@@ -121,23 +172,43 @@ func (m *Model) View() string {
 		content = m.FormatList.View()
 	case types.StateDownload:
 		content = m.Download.View()
+	case types.StateQueue:
+		content = m.Queue.View()
+	case types.StateLibrary:
+		content = m.Library.View()
+	case types.StateHistory:
+		content = m.History.View()
+	case types.StateRecent:
+		content = m.Recent.View()
 	}
 
 	statusCfg := StatusBarConfig{
-		HasError:      m.VideoList.ErrMsg != "",
-		HelpVisible:   m.Search.Help.Visible,
-		IsPaused:      m.Download.Paused,
-		IsCompleted:   m.Download.Completed,
-		IsCancelled:   m.Download.Cancelled,
-		Keys:          models.GetStatusKeys(m.State, m.Search.Help.Visible, m.Search.ResumeList.Visible),
-		ResumeVisible: m.Search.ResumeList.Visible,
+		HasError:       m.VideoList.ErrMsg != "",
+		HelpVisible:    m.Search.Help.Visible,
+		IsPaused:       m.Download.Paused,
+		IsCompleted:    m.Download.Completed,
+		IsCancelled:    m.Download.Cancelled,
+		Keys:           models.GetStatusKeys(m.State, m.Search.Help.Visible, m.Search.ResumeList.Visible),
+		ResumeVisible:  m.Search.ResumeList.Visible,
+		BatchPrompt:    m.VideoList.BatchPrompt,
+		Collection:     m.VideoList.Collection.Visible,
+		CanDownloadAll: m.VideoList.IsChannelSearch || m.VideoList.IsPlaylistSearch,
 	}
 
 	left := getStatusBarText(m.State, statusCfg, m.Search.Help.Keys)
 
 	right := ""
-	if m.ErrMsg != "" {
+	if m.Duplicate != nil {
+		entry := m.Duplicate.Entry
+		right = lipgloss.NewStyle().Foreground(styles.WarningColor).Render(fmt.Sprintf("⚠ Already in library: %s (%s) — download again? y/n", entry.Title, entry.FilePath))
+	} else if m.ResumePrompt > 0 {
+		right = lipgloss.NewStyle().Foreground(styles.WarningColor).Render(fmt.Sprintf("↻ %d unfinished downloads — resume all? y/n", m.ResumePrompt))
+	} else if m.ErrMsg != "" {
 		right = lipgloss.NewStyle().Foreground(styles.ErrorColor).Render("⚠ " + m.ErrMsg)
+	} else if m.Notice != "" {
+		right = lipgloss.NewStyle().Foreground(styles.SuccessColor).Render("✓ " + m.Notice)
+	} else if active := m.DownloadQueue.ActiveCount(); active > 0 && m.State != types.StateDownload && m.State != types.StateQueue {
+		right = lipgloss.NewStyle().Foreground(styles.InfoColor).Render(fmt.Sprintf("⇣ %d active downloads", active))
 	}
 
 	var statusBar string
@@ -149,7 +220,18 @@ func (m *Model) View() string {
 		rightSpace := availableWidth - leftWidth
 
 		if rightWidth > rightSpace && rightSpace > 0 {
-			right = lipgloss.NewStyle().Foreground(styles.ErrorColor).Width(rightSpace).MaxWidth(rightSpace).Render("⚠ " + m.ErrMsg)
+			switch {
+			case m.Duplicate != nil:
+				right = lipgloss.NewStyle().Foreground(styles.WarningColor).Width(rightSpace).MaxWidth(rightSpace).Render("⚠ Already in library — download again? y/n")
+			case m.ResumePrompt > 0:
+				right = lipgloss.NewStyle().Foreground(styles.WarningColor).Width(rightSpace).MaxWidth(rightSpace).Render("↻ Resume all? y/n")
+			case m.ErrMsg != "":
+				right = lipgloss.NewStyle().Foreground(styles.ErrorColor).Width(rightSpace).MaxWidth(rightSpace).Render("⚠ " + m.ErrMsg)
+			case m.Notice != "":
+				right = lipgloss.NewStyle().Foreground(styles.SuccessColor).Width(rightSpace).MaxWidth(rightSpace).Render("✓ " + m.Notice)
+			default:
+				right = lipgloss.NewStyle().Foreground(styles.InfoColor).Width(rightSpace).MaxWidth(rightSpace).Render(fmt.Sprintf("⇣ %d active", m.DownloadQueue.ActiveCount()))
+			}
 		}
 
 		statusBar = styles.StatusBarStyle.Height(1).Width(m.Width).Render(left + lipgloss.PlaceHorizontal(availableWidth-leftWidth, lipgloss.Right, right))
@@ -179,6 +261,8 @@ func (m *Model) LoadingView() string {
 		loadingText = "Loading videos for channel " + styles.SpinnerStyle.Render("@"+m.VideoList.ChannelName)
 	case "playlist":
 		loadingText = fmt.Sprintf("Searching playlist: %s", styles.SpinnerStyle.Render(m.CurrentQuery))
+	case "feed":
+		loadingText = "Loading subscriptions feed..."
 	}
 
 	fmt.Fprintf(&s, "\n%s %s\n", m.Spinner.View(), loadingText)
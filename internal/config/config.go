@@ -10,20 +10,39 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-const ConfigFileName = "config.yaml"
+const (
+	ConfigFileName  = "config.yaml"
+	ArchiveFileName = "archive.txt"
+)
 
 type Config struct {
-	SearchLimit         int    `yaml:"search_limit"`
-	DefaultDownloadPath string `yaml:"default_download_path"`
-	DefaultFormat       string `yaml:"default_format"`
-	SortByDefault       string `yaml:"sort_by_default"`
-	EmbedSubtitles      bool   `yaml:"embed_subtitles"`
-	EmbedMetadata       bool   `yaml:"embed_metadata"`
-	EmbedChapters       bool   `yaml:"embed_chapters"`
-	FFmpegPath          string `yaml:"ffmpeg_path"`
-	YTDLPPath           string `yaml:"yt_dlp_path"`
-	CookiesBrowser      string `yaml:"cookies_browser"`
-	CookiesFile         string `yaml:"cookies_file"`
+	SearchLimit            int                `yaml:"search_limit"`
+	DefaultDownloadPath    string             `yaml:"default_download_path"`
+	DefaultFormat          string             `yaml:"default_format"`
+	SortByDefault          string             `yaml:"sort_by_default"`
+	EmbedSubtitles         bool               `yaml:"embed_subtitles"`
+	EmbedMetadata          bool               `yaml:"embed_metadata"`
+	EmbedChapters          bool               `yaml:"embed_chapters"`
+	LiveFromStart          bool               `yaml:"live_from_start"`
+	WaitForVideo           bool               `yaml:"wait_for_video"`
+	FFmpegPath             string             `yaml:"ffmpeg_path"`
+	YTDLPPath              string             `yaml:"yt_dlp_path"`
+	CookiesBrowser         string             `yaml:"cookies_browser"`
+	CookiesFile            string             `yaml:"cookies_file"`
+	MaxConcurrentDownloads int                `yaml:"max_concurrent_downloads"`
+	FormatPresets          []FormatPreset     `yaml:"format_presets"`
+	AutoPickPreset         string             `yaml:"auto_pick_preset"`
+	OutputTemplates        OutputTemplates    `yaml:"output_templates"`
+	DownloadArchive        string             `yaml:"download_archive"`
+	AutoDownloadRules      []AutoDownloadRule `yaml:"auto_download_rules"`
+	SyncOnStartup          bool               `yaml:"sync_on_startup"`
+}
+
+type OutputTemplates struct {
+	Video    string `yaml:"video"`
+	Audio    string `yaml:"audio"`
+	Playlist string `yaml:"playlist"`
+	Channel  string `yaml:"channel"`
 }
 
 func GetConfigDir() string {
@@ -96,6 +115,30 @@ func (c *Config) applyDefaults() {
 	if c.SortByDefault == "" {
 		c.SortByDefault = defaults.SortByDefault
 	}
+
+	if c.MaxConcurrentDownloads <= 0 {
+		c.MaxConcurrentDownloads = defaults.MaxConcurrentDownloads
+	}
+
+	if c.FormatPresets == nil {
+		c.FormatPresets = defaults.FormatPresets
+	}
+
+	if c.OutputTemplates.Video == "" {
+		c.OutputTemplates.Video = defaults.OutputTemplates.Video
+	}
+
+	if c.OutputTemplates.Audio == "" {
+		c.OutputTemplates.Audio = defaults.OutputTemplates.Audio
+	}
+
+	if c.OutputTemplates.Playlist == "" {
+		c.OutputTemplates.Playlist = defaults.OutputTemplates.Playlist
+	}
+
+	if c.OutputTemplates.Channel == "" {
+		c.OutputTemplates.Channel = defaults.OutputTemplates.Channel
+	}
 }
 
 func (c *Config) ExpandPath(path string) string {
@@ -112,3 +155,11 @@ func (c *Config) ExpandPath(path string) string {
 func (c *Config) GetDownloadPath() string {
 	return c.ExpandPath(c.DefaultDownloadPath)
 }
+
+func (c *Config) GetDownloadArchivePath() string {
+	if c.DownloadArchive != "" {
+		return c.ExpandPath(c.DownloadArchive)
+	}
+
+	return filepath.Join(paths.GetDataDir(), ArchiveFileName)
+}
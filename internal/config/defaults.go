@@ -2,14 +2,25 @@ package config
 
 func GetDefault() *Config {
 	return &Config{
-		SearchLimit:         25,
-		DefaultDownloadPath: "~/Videos",
-		DefaultFormat:       "bv*+ba/b",
-		SortByDefault:       "relevance",
-		EmbedSubtitles:      false,
-		EmbedMetadata:       true,
-		EmbedChapters:       true,
-		CookiesBrowser:      "",
-		CookiesFile:         "",
+		SearchLimit:            25,
+		DefaultDownloadPath:    "~/Videos",
+		DefaultFormat:          "bv*+ba/b",
+		SortByDefault:          "relevance",
+		EmbedSubtitles:         false,
+		EmbedMetadata:          true,
+		EmbedChapters:          true,
+		LiveFromStart:          false,
+		WaitForVideo:           false,
+		CookiesBrowser:         "",
+		CookiesFile:            "",
+		MaxConcurrentDownloads: 2,
+		FormatPresets:          defaultFormatPresets(),
+		AutoPickPreset:         "",
+		OutputTemplates: OutputTemplates{
+			Video:    "%(title)s.%(ext)s",
+			Audio:    "%(artist)s - %(title)s.%(ext)s",
+			Playlist: "%(playlist_title)s/%(playlist_index)03d - %(title)s.%(ext)s",
+			Channel:  "%(uploader)s/%(upload_date>%Y-%m-%d)s - %(title)s.%(ext)s",
+		},
 	}
 }
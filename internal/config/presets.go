@@ -0,0 +1,60 @@
+package config
+
+import "strings"
+
+const DefaultPresetName = "default"
+
+type FormatPreset struct {
+	Name        string `yaml:"name"`
+	Format      string `yaml:"format"`
+	AudioFormat string `yaml:"audio_format,omitempty"`
+}
+
+func (p FormatPreset) IsAudio() bool {
+	return p.AudioFormat != ""
+}
+
+func defaultFormatPresets() []FormatPreset {
+	return []FormatPreset{
+		{Name: "1080p-mp4", Format: "bv*[height<=1080][ext=mp4]+ba[ext=m4a]/b[height<=1080][ext=mp4]/b[height<=1080]"},
+		{Name: "audio-opus", Format: "ba[acodec=opus]/ba", AudioFormat: "opus"},
+		{Name: "archive-best", Format: "bv*+ba/b"},
+	}
+}
+
+func (c *Config) Presets() []FormatPreset {
+	presets := []FormatPreset{{Name: DefaultPresetName, Format: c.DefaultFormat}}
+	for _, preset := range c.FormatPresets {
+		if preset.Name == "" || preset.Format == "" {
+			continue
+		}
+
+		if strings.EqualFold(preset.Name, DefaultPresetName) {
+			presets[0] = preset
+			continue
+		}
+
+		presets = append(presets, preset)
+	}
+
+	return presets
+}
+
+func (c *Config) FindPreset(name string) (FormatPreset, bool) {
+	for _, preset := range c.Presets() {
+		if strings.EqualFold(preset.Name, strings.TrimSpace(name)) {
+			return preset, true
+		}
+	}
+
+	return FormatPreset{}, false
+}
+
+func (c *Config) PresetNames() []string {
+	var names []string
+	for _, preset := range c.Presets() {
+		names = append(names, preset.Name)
+	}
+
+	return names
+}
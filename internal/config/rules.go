@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type AutoDownloadRule struct {
+	Name           string `yaml:"name,omitempty"`
+	Channel        string `yaml:"channel,omitempty"`
+	Title          string `yaml:"title,omitempty"`
+	MinDuration    string `yaml:"min_duration,omitempty"`
+	MaxDuration    string `yaml:"max_duration,omitempty"`
+	UploadedAfter  string `yaml:"uploaded_after,omitempty"`
+	UploadedBefore string `yaml:"uploaded_before,omitempty"`
+	Preset         string `yaml:"preset,omitempty"`
+	Format         string `yaml:"format,omitempty"`
+	OutputPath     string `yaml:"output_path,omitempty"`
+	Limit          int    `yaml:"limit,omitempty"`
+}
+
+func (r AutoDownloadRule) Label() string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.Channel != "":
+		return r.Channel
+	default:
+		return "all subscriptions"
+	}
+}
+
+func (r AutoDownloadRule) Validate() error {
+	for _, d := range []string{r.MinDuration, r.MaxDuration} {
+		if d == "" {
+			continue
+		}
+
+		if _, err := time.ParseDuration(d); err != nil {
+			return fmt.Errorf("rule %q: invalid duration %q, use e.g. 90s, 30m or 1h30m", r.Label(), d)
+		}
+	}
+
+	if r.Preset != "" && r.Format != "" {
+		return fmt.Errorf("rule %q: preset and format cannot be used together", r.Label())
+	}
+
+	return nil
+}
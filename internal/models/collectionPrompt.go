@@ -0,0 +1,258 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/config"
+	"github.com/xdagiz/xytz/internal/styles"
+	"github.com/xdagiz/xytz/internal/types"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	collectionFieldFormat = iota
+	collectionFieldItems
+	collectionFieldNewest
+	collectionFieldAfter
+	collectionFieldBefore
+	collectionFieldCount
+)
+
+var (
+	playlistItemsRegex = regexp.MustCompile(`^-?\d*(:-?\d*){0,2}(,-?\d*(:-?\d*){0,2})*$`)
+	dateRegex          = regexp.MustCompile(`^(\d{8}|(now|today|yesterday)(-\d+(day|week|month|year)s?)?)$`)
+)
+
+var collectionFieldLabels = [collectionFieldCount]string{
+	"Format",
+	"Items",
+	"Newest",
+	"After",
+	"Before",
+}
+
+type CollectionPromptModel struct {
+	Visible   bool
+	URL       string
+	Title     string
+	IsChannel bool
+	Audio     bool
+	Focus     int
+	Inputs    []textinput.Model
+	ErrMsg    string
+}
+
+func NewCollectionPromptModel() CollectionPromptModel {
+	placeholders := [collectionFieldCount]string{
+		"",
+		"1:10,15,-3: (empty for all)",
+		"download only the newest N entries",
+		"YYYYMMDD or today-2weeks",
+		"YYYYMMDD or today-2weeks",
+	}
+
+	inputs := make([]textinput.Model, collectionFieldCount)
+	for i := range inputs {
+		ti := textinput.New()
+		ti.Prompt = "❯ "
+		ti.PromptStyle = styles.FormatCustomInputPrompt
+		ti.PlaceholderStyle = ti.PlaceholderStyle.Foreground(styles.MutedColor)
+		ti.TextStyle = ti.TextStyle.Foreground(styles.SecondaryColor)
+		ti.Placeholder = placeholders[i]
+		inputs[i] = ti
+	}
+
+	return CollectionPromptModel{Inputs: inputs}
+}
+
+func (m *CollectionPromptModel) Open(url, title string, isChannel bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	m.Visible = true
+	m.URL = url
+	m.Title = title
+	m.IsChannel = isChannel
+	m.Audio = false
+	m.ErrMsg = ""
+	for i := range m.Inputs {
+		m.Inputs[i].SetValue("")
+	}
+	m.Inputs[collectionFieldFormat].Placeholder = cfg.DefaultFormat
+	m.Inputs[collectionFieldFormat].SetValue(cfg.DefaultFormat)
+	m.Inputs[collectionFieldFormat].CursorEnd()
+	m.focus(collectionFieldFormat)
+}
+
+func (m *CollectionPromptModel) Close() {
+	m.Visible = false
+	for i := range m.Inputs {
+		m.Inputs[i].Blur()
+	}
+}
+
+func (m *CollectionPromptModel) SetWidth(w int) {
+	for i := range m.Inputs {
+		m.Inputs[i].Width = w - 18
+	}
+}
+
+func (m *CollectionPromptModel) focus(field int) {
+	m.Focus = (field + collectionFieldCount) % collectionFieldCount
+	for i := range m.Inputs {
+		if i == m.Focus {
+			m.Inputs[i].Focus()
+		} else {
+			m.Inputs[i].Blur()
+		}
+	}
+}
+
+func (m CollectionPromptModel) value(field int) string {
+	return strings.TrimSpace(m.Inputs[field].Value())
+}
+
+func (m CollectionPromptModel) playlistItems() (string, error) {
+	items := strings.ReplaceAll(m.value(collectionFieldItems), " ", "")
+	if items != "" {
+		if !playlistItemsRegex.MatchString(items) {
+			return "", fmt.Errorf("invalid item range %q", items)
+		}
+		return items, nil
+	}
+
+	newest := m.value(collectionFieldNewest)
+	if newest == "" {
+		return "", nil
+	}
+
+	n, err := strconv.Atoi(newest)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("newest must be a positive number")
+	}
+
+	// Channels list uploads newest first, playlists keep their own order.
+	if m.IsChannel {
+		return fmt.Sprintf("1:%d", n), nil
+	}
+
+	return fmt.Sprintf("-%d:", n), nil
+}
+
+func (m CollectionPromptModel) submit() (tea.Msg, error) {
+	formatID := m.value(collectionFieldFormat)
+	if formatID == "" {
+		return nil, fmt.Errorf("format is required")
+	}
+
+	items, err := m.playlistItems()
+	if err != nil {
+		return nil, err
+	}
+
+	after := m.value(collectionFieldAfter)
+	before := m.value(collectionFieldBefore)
+	for _, date := range []string{after, before} {
+		if date != "" && !dateRegex.MatchString(date) {
+			return nil, fmt.Errorf("invalid date %q", date)
+		}
+	}
+
+	return types.StartCollectionDownloadMsg{
+		URL:           m.URL,
+		Title:         m.Title,
+		FormatID:      formatID,
+		IsAudio:       m.Audio,
+		PlaylistItems: items,
+		DateAfter:     after,
+		DateBefore:    before,
+	}, nil
+}
+
+func (m CollectionPromptModel) Update(msg tea.Msg) (CollectionPromptModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc":
+		m.Close()
+		return m, nil
+
+	case "tab", "down":
+		m.focus(m.Focus + 1)
+		return m, nil
+
+	case "shift+tab", "up":
+		m.focus(m.Focus - 1)
+		return m, nil
+
+	case "ctrl+a":
+		m.Audio = !m.Audio
+		format := &m.Inputs[collectionFieldFormat]
+		if m.Audio {
+			format.SetValue("bestaudio/best")
+		} else {
+			format.SetValue(format.Placeholder)
+		}
+		format.CursorEnd()
+		return m, nil
+
+	case "enter":
+		result, err := m.submit()
+		if err != nil {
+			m.ErrMsg = err.Error()
+			return m, nil
+		}
+
+		m.Close()
+		return m, func() tea.Msg { return result }
+	}
+
+	m.ErrMsg = ""
+	var cmd tea.Cmd
+	m.Inputs[m.Focus], cmd = m.Inputs[m.Focus].Update(msg)
+	return m, cmd
+}
+
+func (m CollectionPromptModel) View() string {
+	var s strings.Builder
+
+	kind := "playlist"
+	if m.IsChannel {
+		kind = "channel"
+	}
+	mode := "video"
+	if m.Audio {
+		mode = "audio (mp3)"
+	}
+
+	s.WriteString(styles.MutedStyle.Render(fmt.Sprintf("Download entire %s %s, %s (ctrl+a to switch)", kind, m.Title, mode)))
+	s.WriteRune('\n')
+
+	for i, input := range m.Inputs {
+		label := fmt.Sprintf("%-7s", collectionFieldLabels[i])
+		if i == m.Focus {
+			label = styles.FormatCustomInputPrompt.Render(label)
+		} else {
+			label = styles.MutedStyle.Render(label)
+		}
+		s.WriteString(label + " " + input.View())
+		s.WriteRune('\n')
+	}
+
+	if m.ErrMsg != "" {
+		s.WriteString(styles.ErrorMessageStyle.Render(m.ErrMsg))
+		s.WriteRune('\n')
+	}
+
+	return styles.InputStyle.Render(strings.TrimSuffix(s.String(), "\n"))
+}
@@ -17,17 +17,31 @@ import (
 
 type DownloadModel struct {
 	Progress        progress.Model
+	Overall         progress.Model
 	SelectedVideo   types.VideoItem
 	CurrentSpeed    string
 	CurrentETA      string
-	Phase           string
+	Status          string
+	Phase           types.DownloadPhase
 	Completed       bool
 	Paused          bool
 	Cancelled       bool
 	Destination     string
 	FileDestination string
 	FileExtension   string
-	DownloadManager *utils.DownloadManager
+	ItemIndex       int
+	ItemCount       int
+	DownloadedBytes int64
+	TotalBytes      int64
+	FragmentIndex   int
+	FragmentCount   int
+	Live            bool
+	Elapsed         float64
+	FilePath        string
+	FilePaths       []string
+	Notice          string
+	JobID           int
+	DownloadQueue   *utils.DownloadQueue
 }
 
 func NewDownloadModel() DownloadModel {
@@ -37,12 +51,48 @@ func NewDownloadModel() DownloadModel {
 	destination := cfg.GetDownloadPath()
 
 	return DownloadModel{
-		Progress:        pr,
-		Destination:     destination,
-		DownloadManager: utils.NewDownloadManager(),
+		Progress:    pr,
+		Overall:     progress.New(progress.WithSolidFill(string(styles.MauveColor))),
+		Destination: destination,
 	}
 }
 
+func (m *DownloadModel) Track(jobID int, video types.VideoItem) tea.Cmd {
+	m.JobID = jobID
+	m.SelectedVideo = video
+	m.Completed = false
+	m.Paused = false
+	m.Cancelled = false
+	m.CurrentSpeed = ""
+	m.CurrentETA = ""
+	m.Status = ""
+	m.Phase = ""
+	m.FileDestination = ""
+	m.FileExtension = ""
+	m.ItemIndex = 0
+	m.ItemCount = 0
+	m.DownloadedBytes = 0
+	m.TotalBytes = 0
+	m.FragmentIndex = 0
+	m.FragmentCount = 0
+	m.Live = video.IsLive()
+	m.Elapsed = 0
+	m.FilePath = ""
+	m.FilePaths = nil
+	m.Notice = ""
+
+	return m.Progress.SetPercent(0)
+}
+
+func (m DownloadModel) IsQueued() bool {
+	if m.DownloadQueue == nil || m.JobID == 0 {
+		return false
+	}
+
+	job, ok := m.DownloadQueue.Job(m.JobID)
+	return ok && job.State == types.JobQueued
+}
+
 func (m DownloadModel) Init() tea.Cmd {
 	return tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
 		return progress.FrameMsg{}
@@ -54,44 +104,83 @@ func (m DownloadModel) Update(msg tea.Msg) (DownloadModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case types.ProgressMsg:
+		if msg.JobID != m.JobID {
+			return m, nil
+		}
+
 		cmd = m.Progress.SetPercent(msg.Percent / 100.0)
 		m.CurrentSpeed = msg.Speed
 		m.CurrentETA = msg.Eta
-		m.Phase = msg.Status
+		m.Status = msg.Status
+		m.Phase = msg.Phase
 		if msg.Destination != "" {
 			m.FileDestination = msg.Destination
 		}
 		if msg.FileExtension != "" {
 			m.FileExtension = msg.FileExtension
 		}
+		if msg.ItemCount > 0 {
+			m.ItemIndex = msg.ItemIndex
+			m.ItemCount = msg.ItemCount
+		}
+		m.DownloadedBytes = msg.DownloadedBytes
+		m.TotalBytes = msg.TotalBytes
+		m.FragmentIndex = msg.FragmentIndex
+		m.FragmentCount = msg.FragmentCount
+		m.Live = m.Live || msg.Live
+		m.Elapsed = msg.Elapsed
 
 	case types.PauseDownloadMsg:
-		m.Paused = true
+		if msg.JobID == m.JobID {
+			m.Paused = true
+		}
 
 	case types.ResumeDownloadMsg:
-		m.Paused = false
+		if msg.JobID == m.JobID {
+			m.Paused = false
+		}
 
 	case types.CancelDownloadMsg:
-		m.Cancelled = true
+		if msg.JobID == m.JobID {
+			m.Cancelled = true
+		}
 
 	case tea.KeyMsg:
-		if m.Completed || m.Cancelled && msg.Type == tea.KeyEnter {
+		if (m.Completed || m.Cancelled) && msg.Type == tea.KeyEnter {
 			cmd = func() tea.Msg {
 				return types.DownloadCompleteMsg{}
 			}
 		}
 
+		if m.Completed && m.FilePath != "" {
+			switch msg.String() {
+			case "o":
+				utils.OpenPath(m.FilePath)
+				m.Notice = "Opening " + m.FilePath
+			case "f":
+				utils.OpenFolder(m.FilePath)
+				m.Notice = "Opening " + filepath.Dir(m.FilePath)
+			case "y":
+				if err := utils.CopyToClipboard(m.FilePath); err != nil {
+					m.Notice = "Failed to copy path: " + err.Error()
+				} else {
+					m.Notice = "Path copied to clipboard"
+				}
+			}
+		}
+
 		if !m.Completed && !m.Cancelled {
 			switch msg.String() {
 			case "p", " ":
 				if m.Paused {
-					cmd = utils.ResumeDownload(m.DownloadManager)
+					cmd = utils.ResumeDownload(m.DownloadQueue, m.JobID)
 				} else {
-					cmd = utils.PauseDownload(m.DownloadManager)
+					cmd = utils.PauseDownload(m.DownloadQueue, m.JobID)
 				}
 			case "c", "esc":
+				jobID := m.JobID
 				cmd = func() tea.Msg {
-					return types.CancelDownloadMsg{}
+					return types.CancelDownloadMsg{JobID: jobID}
 				}
 			}
 		}
@@ -111,6 +200,7 @@ func (m DownloadModel) HandleResize(w, h int) DownloadModel {
 	} else {
 		m.Progress.Width = w - 10
 	}
+	m.Overall.Width = m.Progress.Width
 
 	return m
 }
@@ -121,12 +211,20 @@ func (m DownloadModel) View() string {
 	if m.SelectedVideo.ID != "" {
 		s.WriteString(styles.SectionHeaderStyle.Render(m.SelectedVideo.Title()))
 		s.WriteRune('\n')
-		s.WriteString(styles.MutedStyle.Render(fmt.Sprintf("⏱  %s", utils.FormatDuration(m.SelectedVideo.Duration))))
-		s.WriteRune('\n')
-		s.WriteString(styles.MutedStyle.Render(fmt.Sprintf("👁  %s views", utils.FormatNumber(m.SelectedVideo.Views))))
-		s.WriteRune('\n')
+		if m.SelectedVideo.IsLive() {
+			s.WriteString(styles.MutedStyle.Render("● " + m.SelectedVideo.Kind.Label()))
+			s.WriteRune('\n')
+		} else {
+			s.WriteString(styles.MutedStyle.Render(fmt.Sprintf("⏱  %s", utils.FormatDuration(m.SelectedVideo.Duration))))
+			s.WriteRune('\n')
+			s.WriteString(styles.MutedStyle.Render(fmt.Sprintf("👁  %s views", utils.FormatNumber(m.SelectedVideo.Views))))
+			s.WriteRune('\n')
+		}
 		s.WriteString(styles.MutedStyle.Render(fmt.Sprintf("📺 %s", m.SelectedVideo.Channel)))
 		s.WriteRune('\n')
+	} else if m.SelectedVideo.VideoTitle != "" {
+		s.WriteString(styles.SectionHeaderStyle.Render(m.SelectedVideo.VideoTitle))
+		s.WriteRune('\n')
 	}
 
 	statusText := "⇣ Downloading"
@@ -136,8 +234,17 @@ func (m DownloadModel) View() string {
 		statusText = "⏸ Paused"
 	} else if m.Cancelled {
 		statusText = "✕ Cancelled"
-	} else if m.Phase != "" {
-		formatInfo := strings.TrimPrefix(m.Phase, "[download] ")
+	} else if m.IsQueued() {
+		statusText = "⋯ Queued, waiting for a free download slot"
+	} else if m.Phase.IsPostProcessing() {
+		statusText = "⚙ " + phaseTitle(m.Phase)
+	} else if m.Live {
+		statusText = "● Recording live stream"
+		if m.DownloadedBytes == 0 && m.Elapsed == 0 && m.SelectedVideo.Kind == types.VideoKindUpcoming {
+			statusText = "⋯ Waiting for the stream to start"
+		}
+	} else if m.Status != "" {
+		formatInfo := strings.TrimPrefix(m.Status, "[download] ")
 		if formatInfo != "" && formatInfo != "[download]" {
 			statusText = "⇣ Downloading " + formatInfo
 		} else {
@@ -145,38 +252,108 @@ func (m DownloadModel) View() string {
 		}
 	}
 
+	if m.ItemCount > 0 && !m.Completed && !m.Cancelled && !m.Paused {
+		phase := types.PhaseDownloading
+		if m.Phase != "" {
+			phase = m.Phase
+		}
+		statusText = fmt.Sprintf("⇣ Item %d/%d — %s", m.ItemIndex, m.ItemCount, phase)
+	}
+
 	s.WriteString(styles.SectionHeaderStyle.Render(statusText))
 	s.WriteRune('\n')
 
 	if m.Completed {
-		title := m.SelectedVideo.Title()
-		ext := ".mp4"
-		if m.FileExtension != "" {
-			ext = m.FileExtension
+		switch {
+		case len(m.FilePaths) > 1:
+			s.WriteString(styles.CompletionMessageStyle.Render(fmt.Sprintf("%d files saved to %s", len(m.FilePaths), filepath.Dir(m.FilePath))))
+		case m.FilePath != "":
+			s.WriteString(styles.CompletionMessageStyle.Render("Saved to " + m.FilePath))
+		case m.ItemCount > 0:
+			s.WriteString(styles.CompletionMessageStyle.Render(fmt.Sprintf("%d items saved to %s", m.ItemCount, m.Destination)))
+		default:
+			s.WriteString(styles.CompletionMessageStyle.Render("Saved to " + m.Destination))
 		}
-		finalPath := filepath.Join(m.Destination, title+ext)
-		s.WriteString(styles.CompletionMessageStyle.Render("Video saved to " + finalPath))
 		s.WriteRune('\n')
 		s.WriteRune('\n')
-		s.WriteString(styles.HelpStyle.Render("Press Enter to continue"))
+		if m.Notice != "" {
+			s.WriteString(styles.MutedStyle.Render(m.Notice))
+			s.WriteRune('\n')
+		}
+		if m.FilePath != "" {
+			s.WriteString(styles.HelpStyle.Render("o: open file • f: open folder • y: copy path • Enter: continue"))
+		} else {
+			s.WriteString(styles.HelpStyle.Render("Press Enter to continue"))
+		}
 	} else if m.Cancelled {
 		s.WriteString(styles.ErrorMessageStyle.Render("Download was cancelled."))
 		s.WriteRune('\n')
+	} else if m.Live {
+		s.WriteString("Recorded: " + styles.ErrorMessageStyle.Render(utils.FormatDuration(m.Elapsed)))
+		s.WriteRune('\n')
+
+		if m.CurrentSpeed != "" {
+			s.WriteString("Speed: " + styles.SpeedStyle.Render(m.CurrentSpeed))
+			s.WriteRune('\n')
+		}
+
+		if m.DownloadedBytes > 0 {
+			size := utils.FormatBytes(float64(m.DownloadedBytes))
+			if m.FragmentIndex > 0 {
+				size += fmt.Sprintf(" (fragment %d)", m.FragmentIndex)
+			}
+			s.WriteString("Size: " + styles.ProgressStyle.Render(size))
+			s.WriteRune('\n')
+		}
+
+		dest := m.Destination
+		if m.FileDestination != "" {
+			dest = m.FileDestination
+		}
+		s.WriteString("Destination: " + styles.DestinationStyle.Render(dest))
+		s.WriteRune('\n')
 	} else {
 		bar := styles.ProgressContainer.Render(m.Progress.View())
 		s.WriteString(bar)
 		s.WriteRune('\n')
 
+		if m.ItemCount > 0 {
+			overall := utils.OverallPercent(m.ItemIndex, m.ItemCount, m.Progress.Percent()*100)
+			s.WriteString("Overall: " + m.Overall.ViewAs(overall/100.0))
+			s.WriteRune('\n')
+		}
+
 		s.WriteString("Speed: " + styles.SpeedStyle.Render(m.CurrentSpeed))
 		s.WriteRune('\n')
 
 		s.WriteString("Time remaining: " + styles.TimeRemainingStyle.Render(m.CurrentETA))
 		s.WriteRune('\n')
 
+		if m.TotalBytes > 0 {
+			size := fmt.Sprintf("%s of %s", utils.FormatBytes(float64(m.DownloadedBytes)), utils.FormatBytes(float64(m.TotalBytes)))
+			if m.FragmentCount > 0 {
+				size += fmt.Sprintf(" (fragment %d/%d)", m.FragmentIndex, m.FragmentCount)
+			}
+			s.WriteString("Size: " + styles.ProgressStyle.Render(size))
+			s.WriteRune('\n')
+		}
+
 		dest := m.Destination
+		if m.FileDestination != "" {
+			dest = m.FileDestination
+		}
 		s.WriteString("Destination: " + styles.DestinationStyle.Render(dest))
 		s.WriteRune('\n')
 	}
 
 	return s.String()
 }
+
+func phaseTitle(phase types.DownloadPhase) string {
+	text := string(phase)
+	if text == "" {
+		return ""
+	}
+
+	return strings.ToUpper(text[:1]) + text[1:]
+}
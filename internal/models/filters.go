@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/styles"
+	"github.com/xdagiz/xytz/internal/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	filterRowUploadDate = iota
+	filterRowDuration
+	filterRowType
+	filterRowFeatures
+)
+
+type FilterPanelModel struct {
+	Visible bool
+	Cursor  int
+}
+
+func NewFilterPanelModel() FilterPanelModel {
+	return FilterPanelModel{}
+}
+
+func (m *FilterPanelModel) Show() {
+	m.Visible = true
+}
+
+func (m *FilterPanelModel) Hide() {
+	m.Visible = false
+}
+
+func (m *FilterPanelModel) Toggle() {
+	m.Visible = !m.Visible
+}
+
+func (m FilterPanelModel) rows() int {
+	return filterRowFeatures + len(types.Features)
+}
+
+func (m FilterPanelModel) Update(msg tea.KeyMsg, filters *types.SearchFilters) FilterPanelModel {
+	switch msg.String() {
+	case "esc", "enter", "ctrl+f":
+		m.Hide()
+	case "up", "k", "ctrl+p":
+		m.Cursor = (m.Cursor - 1 + m.rows()) % m.rows()
+	case "down", "j", "ctrl+n":
+		m.Cursor = (m.Cursor + 1) % m.rows()
+	case "right", "l", " ", "tab":
+		m.change(filters, 1)
+	case "left", "h", "shift+tab":
+		m.change(filters, -1)
+	case "x", "backspace":
+		*filters = types.SearchFilters{}
+	}
+
+	return m
+}
+
+func (m FilterPanelModel) change(filters *types.SearchFilters, dir int) {
+	switch m.Cursor {
+	case filterRowUploadDate:
+		if dir > 0 {
+			filters.UploadDate = filters.UploadDate.Next()
+		} else {
+			filters.UploadDate = filters.UploadDate.Prev()
+		}
+	case filterRowDuration:
+		if dir > 0 {
+			filters.Duration = filters.Duration.Next()
+		} else {
+			filters.Duration = filters.Duration.Prev()
+		}
+	case filterRowType:
+		if dir > 0 {
+			filters.Type = filters.Type.Next()
+		} else {
+			filters.Type = filters.Type.Prev()
+		}
+	default:
+		filters.ToggleFeature(types.Features[m.Cursor-filterRowFeatures])
+	}
+}
+
+func (m FilterPanelModel) View(filters types.SearchFilters) string {
+	if !m.Visible {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString(styles.SortTitle.Render("Filters"))
+	s.WriteString(styles.SortHelp.Render("(↑/↓ select, ←/→ change, x clear, esc close)"))
+	s.WriteRune('\n')
+
+	row := func(index int, label, value string) {
+		cursor := " "
+		if index == m.Cursor {
+			cursor = ">"
+		}
+		fmt.Fprintf(&s, "%s %-12s %s\n", styles.SortItem.Render(cursor), label, styles.SortItem.Render(value))
+	}
+
+	row(filterRowUploadDate, "Upload date", "< "+filters.UploadDate.GetDisplayName()+" >")
+	row(filterRowDuration, "Duration", "< "+filters.Duration.GetDisplayName()+" >")
+	row(filterRowType, "Type", "< "+filters.Type.GetDisplayName()+" >")
+
+	for i, feature := range types.Features {
+		indicator := "○"
+		if filters.HasFeature(feature) {
+			indicator = "◉"
+		}
+		row(filterRowFeatures+i, feature.GetDisplayName(), indicator)
+	}
+
+	return s.String()
+}
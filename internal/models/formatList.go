@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/xdagiz/xytz/internal/config"
 	"github.com/xdagiz/xytz/internal/styles"
 	"github.com/xdagiz/xytz/internal/types"
 	"github.com/xdagiz/xytz/internal/utils"
@@ -21,9 +22,10 @@ const (
 	FormatTabAudio
 	FormatTabThumbnail
 	FormatTabCustom
+	FormatTabPresets
 )
 
-var formatTabNames = []string{"Video", "Audio", "Thumbnail", "Custom"}
+var formatTabNames = []string{"Video", "Audio", "Thumbnail", "Custom", "Presets"}
 
 type FormatListModel struct {
 	Width            int
@@ -229,8 +231,9 @@ func (m FormatListModel) Update(msg tea.Msg) (FormatListModel, tea.Cmd) {
 				msg := types.StartDownloadMsg{
 					URL:             m.URL,
 					FormatID:        format.FormatValue,
-					IsAudioTab:      m.ActiveTab == FormatTabAudio,
+					IsAudioTab:      m.ActiveTab == FormatTabAudio || format.AudioFormat != "",
 					ABR:             format.ABR,
+					AudioFormat:     format.AudioFormat,
 					DownloadOptions: m.DownloadOptions,
 				}
 				return msg
@@ -258,7 +261,7 @@ func (m FormatListModel) Update(msg tea.Msg) (FormatListModel, tea.Cmd) {
 
 func (m *FormatListModel) nextTab() {
 	m.ActiveTab++
-	if m.ActiveTab > FormatTabCustom {
+	if m.ActiveTab > FormatTabPresets {
 		m.ActiveTab = FormatTabVideo
 	}
 
@@ -268,7 +271,7 @@ func (m *FormatListModel) nextTab() {
 func (m *FormatListModel) prevTab() {
 	m.ActiveTab--
 	if m.ActiveTab < FormatTabVideo {
-		m.ActiveTab = FormatTabCustom
+		m.ActiveTab = FormatTabPresets
 	}
 
 	m.updateListForTab()
@@ -284,6 +287,8 @@ func (m *FormatListModel) updateListForTab() {
 		m.List.SetItems(m.ThumbnailFormats)
 	case FormatTabCustom:
 		m.List.SetItems([]list.Item{})
+	case FormatTabPresets:
+		m.List.SetItems(presetItems())
 	}
 
 	m.List.ResetSelected()
@@ -310,6 +315,31 @@ func (m *FormatListModel) ResetTab() {
 	m.updateListForTab()
 }
 
+func presetItems() []list.Item {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	var items []list.Item
+	for _, preset := range cfg.Presets() {
+		desc := preset.Format
+		if preset.IsAudio() {
+			desc += " → " + preset.AudioFormat
+		}
+
+		items = append(items, types.FormatItem{
+			FormatTitle: preset.Name,
+			FormatValue: preset.Format,
+			Size:        desc,
+			FormatType:  "preset",
+			AudioFormat: preset.AudioFormat,
+		})
+	}
+
+	return items
+}
+
 var (
 	formatTabNext = key.NewBinding(key.WithKeys("tab"))
 	formatTabPrev = key.NewBinding(key.WithKeys("shift+tab"))
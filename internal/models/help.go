@@ -49,20 +49,41 @@ func NewHelpModel() HelpModel {
 			{
 				Title: "commands",
 				Content: ` /channel <username>      Search videos from a channel
+ /shorts <username>       List Shorts from a channel
+ /streams <username>      List live streams from a channel
  /playlist <url or id>    Search video for a playlist
+ /subscribe <username>    Subscribe to a channel
+ /unsubscribe <username>  Unsubscribe from a channel
+ /feed                    Latest uploads of subscribed channels
+ /sync                    Run the auto-download rules
  /resume                  Resume unfinished downloads
+ /downloads               Show the download queue
+ /library                 Browse downloaded videos
+ /history                 Browse, pin and re-run past searches
+ /recent                  Browse recently opened and downloaded videos
  /help                    Show this help message`,
 			},
 			{
 				Title: "navigation",
 				Content: ` ↑ / ctrl+p    Previous search in history
  ↓ / ctrl+n    Next search in history
+ ctrl+t        Open the download queue
+ ctrl+f        Edit the search filters (upload date, duration, type, features)
+ space         Select video in results (a all, i invert, s filtered, x clear)
+ D             Download selected videos with one format
+ A             Download an entire playlist or channel (range, newest N, dates)
+ /             Filter results, also by dur>20m, views>1M or channel:foo
+ o / O         Sort results by duration, views or title / reverse
+ f             Choose a format, even when a preset is auto-picked
+ S             Subscribe to the channel being browsed
+ t             Switch between a channel's videos, Shorts and streams
  b             Go back`,
 			},
 			{
 				Title: "usage",
 				Content: ` - Search for a video or paste URL
  - Select a video from results to choose format
+ - Select a channel or playlist from results to list its videos
  - Choose a download format and start download
  - Press ctrl+c to quit anytime`,
 			},
@@ -2,7 +2,9 @@ package models
 
 import (
 	"log"
+	"strings"
 
+	"github.com/xdagiz/xytz/internal/types"
 	"github.com/xdagiz/xytz/internal/utils"
 )
 
@@ -10,6 +12,7 @@ type HistoryNavigator struct {
 	items         []string
 	index         int
 	originalQuery string
+	lastQuery     string
 }
 
 func NewHistoryNavigator() HistoryNavigator {
@@ -24,19 +27,40 @@ func (h *HistoryNavigator) Load() {
 		log.Printf("Failed to load history: %v", err)
 		h.items = []string{}
 	} else {
-		h.items = history
+		h.items = make([]string, len(history))
+		for i, entry := range history {
+			h.items[i] = entry.Query
+		}
 	}
 }
 
-func (h *HistoryNavigator) Add(query string) {
-	if err := utils.AddToHistory(query); err != nil {
+func (h *HistoryNavigator) Add(query string, sortBy types.SortBy, filters types.SearchFilters) {
+	entry := utils.HistoryEntry{Query: query, Kind: utils.HistoryKindOf(query)}
+	if entry.Kind == utils.HistorySearch {
+		entry.SortBy = string(sortBy)
+		entry.Filters = filters
+	}
+
+	if err := utils.AddToHistory(entry); err != nil {
 		log.Printf("Failed to save history: %v", err)
 	}
 	h.index = -1
 	h.originalQuery = ""
+	h.lastQuery = strings.TrimSpace(query)
 	h.Load()
 }
 
+func (h *HistoryNavigator) SetResultCount(count int) {
+	if h.lastQuery == "" {
+		return
+	}
+
+	if err := utils.SetHistoryResultCount(h.lastQuery, count); err != nil {
+		log.Printf("Failed to save history: %v", err)
+	}
+	h.lastQuery = ""
+}
+
 // Navigate moves through history. dir=+1 goes to older entries, dir=-1 goes to newer.
 // When returning past the newest entry (index -1), the original query is restored.
 func (h *HistoryNavigator) Navigate(dir int, getCurrentValue func() string, setValue func(string)) {
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/styles"
+	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type historyItem struct {
+	entry utils.HistoryEntry
+}
+
+func (i historyItem) Title() string {
+	if i.entry.Pinned {
+		return "★ " + i.entry.Query
+	}
+
+	return i.entry.Query
+}
+
+func (i historyItem) Description() string {
+	parts := []string{string(i.entry.Kind)}
+	if i.entry.SortBy != "" {
+		parts = append(parts, "by "+types.SortBy(i.entry.SortBy).GetDisplayName())
+	}
+	if !i.entry.Filters.IsZero() {
+		parts = append(parts, i.entry.Filters.String())
+	}
+	if i.entry.ResultCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d results", i.entry.ResultCount))
+	}
+	if !i.entry.Timestamp.IsZero() {
+		parts = append(parts, i.entry.Timestamp.Format("2006-01-02 15:04"))
+	}
+
+	return strings.Join(parts, " • ")
+}
+
+func (i historyItem) FilterValue() string {
+	return i.entry.Query + " " + string(i.entry.Kind)
+}
+
+type HistoryBrowserModel struct {
+	Width  int
+	Height int
+	List   list.Model
+	Notice string
+}
+
+func NewHistoryBrowserModel() HistoryBrowserModel {
+	li := list.New([]list.Item{}, styles.NewListDelegate(), 0, 0)
+	li.SetShowStatusBar(false)
+	li.SetShowTitle(false)
+	li.SetShowHelp(false)
+	li.KeyMap.Quit.SetKeys("q")
+	li.FilterInput.Cursor.Style = li.FilterInput.Cursor.Style.Foreground(styles.MauveColor)
+	li.FilterInput.PromptStyle = li.FilterInput.PromptStyle.Foreground(styles.SecondaryColor)
+
+	return HistoryBrowserModel{List: li}
+}
+
+func (m *HistoryBrowserModel) Reload() {
+	history, err := utils.LoadHistory()
+	if err != nil {
+		m.Notice = "Failed to load history: " + err.Error()
+		return
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Pinned && !history[j].Pinned
+	})
+
+	items := make([]list.Item, len(history))
+	for i, entry := range history {
+		items[i] = historyItem{entry: entry}
+	}
+
+	m.List.SetItems(items)
+}
+
+func (m HistoryBrowserModel) IsCapturingKeys() bool {
+	return m.List.FilterState() == list.Filtering
+}
+
+func (m HistoryBrowserModel) HandleResize(w, h int) HistoryBrowserModel {
+	m.Width = w
+	m.Height = h
+	m.List.SetSize(w, h-8)
+	return m
+}
+
+func (m HistoryBrowserModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m HistoryBrowserModel) Update(msg tea.Msg) (HistoryBrowserModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.List.FilterState() != list.Filtering {
+		item, hasItem := m.List.SelectedItem().(historyItem)
+
+		switch keyMsg.String() {
+		case "enter":
+			if hasItem {
+				entry := item.entry
+				return m, func() tea.Msg {
+					return types.RunHistoryMsg{Query: entry.Query, SortBy: entry.SortBy, Filters: entry.Filters}
+				}
+			}
+			return m, nil
+		case "p":
+			if hasItem {
+				pinned := !item.entry.Pinned
+				if err := utils.SetHistoryPinned(item.entry.Query, pinned); err != nil {
+					m.Notice = "Failed to pin: " + err.Error()
+					return m, nil
+				}
+				if pinned {
+					m.Notice = "Pinned " + item.entry.Query
+				} else {
+					m.Notice = "Unpinned " + item.entry.Query
+				}
+				m.Reload()
+			}
+			return m, nil
+		case "x":
+			if hasItem {
+				if err := utils.RemoveFromHistory(item.entry.Query); err != nil {
+					m.Notice = "Failed to delete: " + err.Error()
+				} else {
+					m.Notice = "Deleted " + item.entry.Query
+					m.Reload()
+				}
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	return m, cmd
+}
+
+func (m HistoryBrowserModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(styles.SectionHeaderStyle.Render(fmt.Sprintf("History (%d entries)", len(m.List.Items()))))
+	s.WriteRune('\n')
+
+	if m.Notice != "" {
+		s.WriteString(styles.MutedStyle.Render(m.Notice))
+	}
+	s.WriteRune('\n')
+
+	if len(m.List.Items()) == 0 {
+		s.WriteString(styles.MutedStyle.Render("No searches yet."))
+		s.WriteRune('\n')
+		return s.String()
+	}
+
+	s.WriteString(styles.ListContainer.Render(m.List.View()))
+
+	return s.String()
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/styles"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type libraryItem struct {
+	entry   utils.LibraryEntry
+	missing bool
+}
+
+func (i libraryItem) Title() string {
+	if i.missing {
+		return i.entry.Title + " (missing)"
+	}
+
+	return i.entry.Title
+}
+
+func (i libraryItem) Description() string {
+	parts := []string{}
+	if i.entry.Channel != "" {
+		parts = append(parts, i.entry.Channel)
+	}
+	if i.entry.Size > 0 {
+		parts = append(parts, utils.FormatBytes(float64(i.entry.Size)))
+	}
+	parts = append(parts, i.entry.DownloadedAt.Format("2006-01-02 15:04"))
+	parts = append(parts, i.entry.FilePath)
+
+	return strings.Join(parts, " • ")
+}
+
+func (i libraryItem) FilterValue() string {
+	return i.entry.Title + " " + i.entry.Channel + " " + i.entry.FilePath
+}
+
+type LibraryModel struct {
+	Width         int
+	Height        int
+	List          list.Model
+	ConfirmDelete bool
+	Notice        string
+}
+
+func NewLibraryModel() LibraryModel {
+	li := list.New([]list.Item{}, styles.NewListDelegate(), 0, 0)
+	li.SetShowStatusBar(false)
+	li.SetShowTitle(false)
+	li.SetShowHelp(false)
+	li.KeyMap.Quit.SetKeys("q")
+	li.FilterInput.Cursor.Style = li.FilterInput.Cursor.Style.Foreground(styles.MauveColor)
+	li.FilterInput.PromptStyle = li.FilterInput.PromptStyle.Foreground(styles.SecondaryColor)
+
+	return LibraryModel{List: li}
+}
+
+func (m *LibraryModel) Reload() {
+	entries, err := utils.LoadLibrary()
+	if err != nil {
+		m.Notice = "Failed to load library: " + err.Error()
+		return
+	}
+
+	items := make([]list.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = libraryItem{entry: entry, missing: !entry.Exists()}
+	}
+
+	m.List.SetItems(items)
+	m.ConfirmDelete = false
+}
+
+func (m LibraryModel) IsCapturingKeys() bool {
+	return m.ConfirmDelete || m.List.FilterState() == list.Filtering
+}
+
+func (m LibraryModel) selected() (utils.LibraryEntry, bool) {
+	item, ok := m.List.SelectedItem().(libraryItem)
+	return item.entry, ok
+}
+
+func (m LibraryModel) HandleResize(w, h int) LibraryModel {
+	m.Width = w
+	m.Height = h
+	m.List.SetSize(w, h-8)
+	return m
+}
+
+func (m LibraryModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m LibraryModel) Update(msg tea.Msg) (LibraryModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.List.FilterState() != list.Filtering {
+		entry, hasEntry := m.selected()
+
+		if m.ConfirmDelete {
+			m.ConfirmDelete = false
+			if keyMsg.String() == "y" && hasEntry {
+				if err := utils.RemoveFromLibrary(entry.FilePath, true); err != nil {
+					m.Notice = "Failed to delete: " + err.Error()
+				} else {
+					m.Notice = "Deleted " + entry.FilePath
+					m.Reload()
+				}
+			} else {
+				m.Notice = ""
+			}
+			return m, nil
+		}
+
+		switch keyMsg.String() {
+		case "enter", "o":
+			if hasEntry {
+				utils.OpenPath(entry.FilePath)
+				m.Notice = "Opening " + entry.FilePath
+			}
+			return m, nil
+		case "O":
+			if hasEntry {
+				utils.OpenFolder(entry.FilePath)
+				m.Notice = "Opening folder of " + entry.Title
+			}
+			return m, nil
+		case "y":
+			if hasEntry {
+				if err := utils.CopyToClipboard(entry.FilePath); err != nil {
+					m.Notice = "Failed to copy path: " + err.Error()
+				} else {
+					m.Notice = "Path copied to clipboard"
+				}
+			}
+			return m, nil
+		case "D":
+			if hasEntry {
+				m.ConfirmDelete = true
+			}
+			return m, nil
+		case "x":
+			if hasEntry {
+				if err := utils.RemoveFromLibrary(entry.FilePath, false); err != nil {
+					m.Notice = "Failed to remove: " + err.Error()
+				} else {
+					m.Notice = "Removed " + entry.Title + " from the library"
+					m.Reload()
+				}
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	return m, cmd
+}
+
+func (m LibraryModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(styles.SectionHeaderStyle.Render(fmt.Sprintf("Library (%d downloads)", len(m.List.Items()))))
+	s.WriteRune('\n')
+
+	if entry, ok := m.selected(); ok && m.ConfirmDelete {
+		s.WriteString(styles.ErrorMessageStyle.Render(fmt.Sprintf("Delete %s from disk? (y/n)", entry.FilePath)))
+	} else if m.Notice != "" {
+		s.WriteString(styles.MutedStyle.Render(m.Notice))
+	}
+	s.WriteRune('\n')
+
+	if len(m.List.Items()) == 0 {
+		s.WriteString(styles.MutedStyle.Render("Nothing downloaded yet."))
+		s.WriteRune('\n')
+		return s.String()
+	}
+
+	s.WriteString(styles.ListContainer.Render(m.List.View()))
+
+	return s.String()
+}
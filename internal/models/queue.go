@@ -0,0 +1,267 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/styles"
+	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const queueRowHeight = 3
+
+type QueueModel struct {
+	Width         int
+	Height        int
+	Cursor        int
+	Offset        int
+	Progress      progress.Model
+	DownloadQueue *utils.DownloadQueue
+}
+
+func NewQueueModel() QueueModel {
+	return QueueModel{
+		Progress: progress.New(progress.WithSolidFill(string(styles.InfoColor))),
+	}
+}
+
+func (m QueueModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m QueueModel) HandleResize(w, h int) QueueModel {
+	m.Width = w
+	m.Height = h
+	if w > 100 {
+		m.Progress.Width = (w / 2) - 10
+	} else {
+		m.Progress.Width = w - 30
+	}
+
+	return m
+}
+
+func (m QueueModel) jobs() []utils.DownloadJob {
+	if m.DownloadQueue == nil {
+		return nil
+	}
+
+	return m.DownloadQueue.Jobs()
+}
+
+func (m QueueModel) SelectedJob() (utils.DownloadJob, bool) {
+	jobs := m.jobs()
+	if m.Cursor < 0 || m.Cursor >= len(jobs) {
+		return utils.DownloadJob{}, false
+	}
+
+	return jobs[m.Cursor], true
+}
+
+func (m QueueModel) visibleRows() int {
+	return max(1, (m.Height-7)/queueRowHeight)
+}
+
+func (m *QueueModel) clamp(count int) {
+	if m.Cursor >= count {
+		m.Cursor = count - 1
+	}
+
+	if m.Cursor < 0 {
+		m.Cursor = 0
+	}
+
+	rows := m.visibleRows()
+	if m.Cursor < m.Offset {
+		m.Offset = m.Cursor
+	}
+
+	if m.Cursor >= m.Offset+rows {
+		m.Offset = m.Cursor - rows + 1
+	}
+
+	if m.Offset > max(0, count-rows) {
+		m.Offset = max(0, count-rows)
+	}
+}
+
+func (m QueueModel) Update(msg tea.Msg) (QueueModel, tea.Cmd) {
+	var cmd tea.Cmd
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	jobs := m.jobs()
+
+	switch keyMsg.String() {
+	case "up", "k", "ctrl+p":
+		m.Cursor--
+
+	case "down", "j", "ctrl+n":
+		m.Cursor++
+
+	case "home", "g":
+		m.Cursor = 0
+
+	case "end", "G":
+		m.Cursor = len(jobs) - 1
+
+	case "p", " ":
+		if job, ok := m.SelectedJob(); ok {
+			switch job.State {
+			case types.JobPaused:
+				cmd = utils.ResumeDownload(m.DownloadQueue, job.ID)
+			case types.JobRunning:
+				cmd = utils.PauseDownload(m.DownloadQueue, job.ID)
+			}
+		}
+
+	case "c":
+		if job, ok := m.SelectedJob(); ok && job.State.IsActive() {
+			cmd = func() tea.Msg {
+				return types.CancelDownloadMsg{JobID: job.ID}
+			}
+		}
+
+	case "r":
+		if job, ok := m.SelectedJob(); ok && (job.State == types.JobFailed || job.State == types.JobCancelled) {
+			cmd = utils.RetryDownload(m.DownloadQueue, job.ID)
+		}
+
+	case "x":
+		m.DownloadQueue.ClearFinished()
+
+	case "enter":
+		if job, ok := m.SelectedJob(); ok {
+			cmd = func() tea.Msg {
+				return types.OpenDownloadMsg{JobID: job.ID}
+			}
+		}
+	}
+
+	m.clamp(len(m.jobs()))
+
+	return m, cmd
+}
+
+func (m QueueModel) View() string {
+	var s strings.Builder
+
+	jobs := m.jobs()
+	m.clamp(len(jobs))
+
+	counts := make(map[types.DownloadJobState]int)
+	for _, job := range jobs {
+		counts[job.State]++
+	}
+
+	s.WriteString(styles.SectionHeaderStyle.Render("Downloads"))
+	s.WriteRune('\n')
+
+	var summary []string
+	for _, state := range []types.DownloadJobState{types.JobRunning, types.JobPaused, types.JobQueued, types.JobFailed, types.JobCancelled, types.JobDone} {
+		if counts[state] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+
+	if len(summary) == 0 {
+		s.WriteString(styles.MutedStyle.Render("No downloads yet. Pick a format from the format list to add one."))
+		s.WriteRune('\n')
+		return s.String()
+	}
+
+	s.WriteString(styles.MutedStyle.Render(strings.Join(summary, " • ")))
+	s.WriteString("\n\n")
+
+	end := min(len(jobs), m.Offset+m.visibleRows())
+	for i := m.Offset; i < end; i++ {
+		s.WriteString(m.renderRow(jobs[i], i == m.Cursor))
+	}
+
+	return s.String()
+}
+
+func (m QueueModel) renderRow(job utils.DownloadJob, selected bool) string {
+	var s strings.Builder
+
+	titleWidth := max(10, m.Width-24)
+	title := job.Title()
+	if lipgloss.Width(title) > titleWidth {
+		title = string([]rune(title)[:max(0, titleWidth-3)]) + "..."
+	}
+
+	titleStyle := styles.QueueTitleStyle
+	marker := "  "
+	if selected {
+		titleStyle = styles.QueueSelectedTitleStyle
+		marker = styles.QueueSelectedTitleStyle.Render("▌ ")
+	}
+
+	s.WriteString(marker + titleStyle.Render(title) + " " + queueStateLabel(job.State))
+	s.WriteRune('\n')
+
+	var details string
+	switch job.State {
+	case types.JobRunning, types.JobPaused:
+		if job.Live {
+			details = styles.ErrorMessageStyle.Render("● REC " + utils.FormatDuration(job.Elapsed))
+		} else {
+			details = m.Progress.ViewAs(utils.OverallPercent(job.ItemIndex, job.ItemCount, job.Percent) / 100.0)
+		}
+		if job.ItemCount > 0 {
+			details += "  " + styles.MutedStyle.Render(fmt.Sprintf("item %d/%d", job.ItemIndex, job.ItemCount))
+		}
+		if job.Phase.IsPostProcessing() {
+			details += "  " + styles.MutedStyle.Render(string(job.Phase))
+		}
+		if job.Speed != "" {
+			details += "  " + styles.SpeedStyle.Render(job.Speed)
+		}
+		if job.Eta != "" {
+			details += "  " + styles.TimeRemainingStyle.Render("ETA "+job.Eta)
+		}
+	case types.JobQueued:
+		details = styles.MutedStyle.Render("Waiting for a free download slot")
+	case types.JobFailed:
+		details = styles.ErrorMessageStyle.Render(job.Err)
+	case types.JobCancelled:
+		details = styles.MutedStyle.Render("Cancelled")
+	case types.JobDone:
+		details = styles.CompletionMessageStyle.Render("Finished " + job.FinishedAt.Format("15:04"))
+		if job.ItemCount > 0 {
+			details += "  " + styles.MutedStyle.Render(fmt.Sprintf("%d items", job.ItemCount))
+		}
+	}
+
+	s.WriteString("  " + details)
+	s.WriteString("\n\n")
+
+	return s.String()
+}
+
+func queueStateLabel(state types.DownloadJobState) string {
+	switch state {
+	case types.JobRunning:
+		return styles.QueueStateStyle.Foreground(styles.InfoColor).Render("⇣ downloading")
+	case types.JobPaused:
+		return styles.QueueStateStyle.Foreground(styles.WarningColor).Render("⏸ paused")
+	case types.JobQueued:
+		return styles.QueueStateStyle.Foreground(styles.MutedColor).Render("⋯ queued")
+	case types.JobFailed:
+		return styles.QueueStateStyle.Foreground(styles.ErrorColor).Render("✕ failed")
+	case types.JobCancelled:
+		return styles.QueueStateStyle.Foreground(styles.MutedColor).Render("✕ cancelled")
+	case types.JobDone:
+		return styles.QueueStateStyle.Foreground(styles.SuccessColor).Render("✓ done")
+	default:
+		return ""
+	}
+}
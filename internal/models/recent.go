@@ -0,0 +1,155 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xdagiz/xytz/internal/styles"
+	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type recentItem struct {
+	entry utils.RecentEntry
+}
+
+func (i recentItem) Title() string {
+	return i.entry.Video.Title()
+}
+
+func (i recentItem) Description() string {
+	parts := []string{string(i.entry.Action)}
+	if i.entry.Video.Channel != "" {
+		parts = append(parts, i.entry.Video.Channel)
+	}
+	if i.entry.Video.Duration > 0 {
+		parts = append(parts, utils.FormatDuration(i.entry.Video.Duration))
+	}
+	parts = append(parts, i.entry.Timestamp.Format("2006-01-02 15:04"))
+
+	return strings.Join(parts, " • ")
+}
+
+func (i recentItem) FilterValue() string {
+	return i.entry.Video.VideoTitle + " " + i.entry.Video.Channel + " " + string(i.entry.Action)
+}
+
+type RecentModel struct {
+	Width  int
+	Height int
+	List   list.Model
+	Notice string
+}
+
+func NewRecentModel() RecentModel {
+	li := list.New([]list.Item{}, styles.NewListDelegate(), 0, 0)
+	li.SetShowStatusBar(false)
+	li.SetShowTitle(false)
+	li.SetShowHelp(false)
+	li.KeyMap.Quit.SetKeys("q")
+	li.FilterInput.Cursor.Style = li.FilterInput.Cursor.Style.Foreground(styles.MauveColor)
+	li.FilterInput.PromptStyle = li.FilterInput.PromptStyle.Foreground(styles.SecondaryColor)
+
+	return RecentModel{List: li}
+}
+
+func (m *RecentModel) Reload() {
+	entries, err := utils.LoadRecent()
+	if err != nil {
+		m.Notice = "Failed to load recent videos: " + err.Error()
+		return
+	}
+
+	items := make([]list.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = recentItem{entry: entry}
+	}
+
+	m.List.SetItems(items)
+}
+
+func (m RecentModel) IsCapturingKeys() bool {
+	return m.List.FilterState() == list.Filtering
+}
+
+func (m RecentModel) HandleResize(w, h int) RecentModel {
+	m.Width = w
+	m.Height = h
+	m.List.SetSize(w, h-8)
+	return m
+}
+
+func (m RecentModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m RecentModel) Update(msg tea.Msg) (RecentModel, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.List.FilterState() != list.Filtering {
+		item, hasItem := m.List.SelectedItem().(recentItem)
+
+		switch keyMsg.String() {
+		case "enter":
+			if hasItem {
+				entry := item.entry
+				return m, func() tea.Msg {
+					return types.StartFormatMsg{URL: entry.URL(), SelectedVideo: entry.Video}
+				}
+			}
+			return m, nil
+		case "o":
+			if hasItem {
+				utils.OpenURL(item.entry.URL())
+				m.Notice = "Opening " + item.entry.Video.Title() + " in the browser"
+			}
+			return m, nil
+		case "y":
+			if hasItem {
+				if err := utils.CopyToClipboard(item.entry.URL()); err != nil {
+					m.Notice = "Failed to copy URL: " + err.Error()
+				} else {
+					m.Notice = "URL copied to clipboard"
+				}
+			}
+			return m, nil
+		case "x":
+			if hasItem {
+				if err := utils.RemoveRecent(item.entry.Video.ID, item.entry.Action); err != nil {
+					m.Notice = "Failed to delete: " + err.Error()
+				} else {
+					m.Notice = "Deleted " + item.entry.Video.Title()
+					m.Reload()
+				}
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.List, cmd = m.List.Update(msg)
+	return m, cmd
+}
+
+func (m RecentModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(styles.SectionHeaderStyle.Render(fmt.Sprintf("Recent videos (%d entries)", len(m.List.Items()))))
+	s.WriteRune('\n')
+
+	if m.Notice != "" {
+		s.WriteString(styles.MutedStyle.Render(m.Notice))
+	}
+	s.WriteRune('\n')
+
+	if len(m.List.Items()) == 0 {
+		s.WriteString(styles.MutedStyle.Render("No videos opened or downloaded yet."))
+		s.WriteRune('\n')
+		return s.String()
+	}
+
+	s.WriteString(styles.ListContainer.Render(m.List.View()))
+
+	return s.String()
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/xdagiz/xytz/internal/styles"
 	"github.com/xdagiz/xytz/internal/utils"
@@ -10,14 +12,39 @@ import (
 )
 
 type ResumeItem struct {
-	URL      string
-	TitleVal string
-	FormatID string
+	Download utils.UnfinishedDownload
 }
 
-func (i ResumeItem) Title() string       { return i.TitleVal }
-func (i ResumeItem) Description() string { return i.URL }
-func (i ResumeItem) FilterValue() string { return i.TitleVal + " " + i.URL }
+func (i ResumeItem) Title() string { return i.Download.Title }
+
+func (i ResumeItem) Description() string {
+	req := i.Download.DownloadRequest()
+
+	parts := []string{}
+	if req.IsAudioTab {
+		audioFormat := req.AudioFormat
+		if audioFormat == "" {
+			audioFormat = "mp3"
+		}
+		if req.ABR > 0 {
+			parts = append(parts, fmt.Sprintf("audio %s %dK", audioFormat, int(req.ABR)))
+		} else {
+			parts = append(parts, "audio "+audioFormat)
+		}
+	} else {
+		parts = append(parts, req.FormatID)
+	}
+
+	if i.Download.PartialPath != "" {
+		parts = append(parts, i.Download.PartialPath)
+	} else {
+		parts = append(parts, req.URL)
+	}
+
+	return strings.Join(parts, " • ")
+}
+
+func (i ResumeItem) FilterValue() string { return i.Download.Title + " " + i.Download.URL }
 
 type ResumeModel struct {
 	Visible bool
@@ -67,11 +94,7 @@ func (m *ResumeModel) LoadItems() {
 
 	listItems := make([]list.Item, len(items))
 	for i, item := range items {
-		listItems[i] = ResumeItem{
-			URL:      item.URL,
-			TitleVal: item.Title,
-			FormatID: item.FormatID,
-		}
+		listItems[i] = ResumeItem{Download: item}
 	}
 
 	m.List.SetItems(listItems)
@@ -85,18 +108,14 @@ func (m *ResumeModel) HandleResize(width, height int) {
 
 func (m *ResumeModel) DeleteSelected() {
 	if item, ok := m.List.SelectedItem().(ResumeItem); ok {
-		utils.RemoveUnfinished(item.URL)
+		utils.RemoveUnfinished(item.Download.URL)
 		m.LoadItems()
 	}
 }
 
 func (m *ResumeModel) SelectedItem() *utils.UnfinishedDownload {
 	if item, ok := m.List.SelectedItem().(ResumeItem); ok {
-		return &utils.UnfinishedDownload{
-			URL:      item.URL,
-			Title:    item.TitleVal,
-			FormatID: item.FormatID,
-		}
+		return &item.Download
 	}
 
 	return nil
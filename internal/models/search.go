@@ -21,11 +21,14 @@ import (
 type CLIOptions struct {
 	SearchLimit        int
 	SortBy             string
+	Filters            types.SearchFilters
 	Query              string
 	Channel            string
+	ChannelTab         types.ChannelTab
 	Playlist           string
 	CookiesFromBrowser string
 	Cookies            string
+	Preset             string
 }
 
 type SearchModel struct {
@@ -36,7 +39,9 @@ type SearchModel struct {
 	ResumeList         ResumeModel
 	Help               HelpModel
 	History            HistoryNavigator
+	FilterPanel        FilterPanelModel
 	SortBy             types.SortBy
+	Filters            types.SearchFilters
 	SearchLimit        int
 	DownloadOptions    []types.DownloadOption
 	Options            *CLIOptions
@@ -60,12 +65,14 @@ func NewSearchModelWithOptions(opts *CLIOptions) SearchModel {
 	cfg, _ := config.Load()
 
 	var defaultSort types.SortBy
+	var filters types.SearchFilters
 	var searchLimit int
 	var cookiesFromBrowser string
 	var cookies string
 
 	if opts != nil {
 		defaultSort = types.ParseSortBy(opts.SortBy)
+		filters = opts.Filters
 		searchLimit = opts.SearchLimit
 		cookiesFromBrowser = opts.CookiesFromBrowser
 		cookies = opts.Cookies
@@ -87,6 +94,10 @@ func NewSearchModelWithOptions(opts *CLIOptions) SearchModel {
 			options[i].Enabled = cfg.EmbedMetadata
 		case "EmbedChapters":
 			options[i].Enabled = cfg.EmbedChapters
+		case "LiveFromStart":
+			options[i].Enabled = cfg.LiveFromStart
+		case "WaitForVideo":
+			options[i].Enabled = cfg.WaitForVideo
 		}
 	}
 
@@ -96,7 +107,9 @@ func NewSearchModelWithOptions(opts *CLIOptions) SearchModel {
 		ResumeList:         NewResumeModel(),
 		Help:               NewHelpModel(),
 		History:            NewHistoryNavigator(),
+		FilterPanel:        NewFilterPanelModel(),
 		SortBy:             defaultSort,
+		Filters:            filters,
 		SearchLimit:        searchLimit,
 		DownloadOptions:    options,
 		Options:            opts,
@@ -144,6 +157,9 @@ func (m SearchModel) View() string {
 			s.WriteString("\n")
 			s.WriteString(helpView)
 		}
+	} else if m.FilterPanel.Visible {
+		s.WriteRune('\n')
+		s.WriteString(m.FilterPanel.View(m.Filters))
 	} else {
 		s.WriteRune('\n')
 		s.WriteString(styles.SortTitle.Render("Sort By"))
@@ -152,6 +168,15 @@ func (m SearchModel) View() string {
 		currentSort := styles.SortItem.Render(">", m.SortBy.GetDisplayName())
 		s.WriteString(currentSort)
 		s.WriteRune('\n')
+		s.WriteString(styles.SortTitle.Render("Filters"))
+		s.WriteString(styles.SortHelp.Render("(ctrl+f to edit)"))
+		s.WriteRune('\n')
+		currentFilters := "None"
+		if !m.Filters.IsZero() {
+			currentFilters = m.Filters.String()
+		}
+		s.WriteString(styles.SortItem.Render(">", currentFilters))
+		s.WriteRune('\n')
 		s.WriteString(styles.SortTitle.Render("Download Options"))
 		s.WriteRune('\n')
 
@@ -192,6 +217,11 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 		}
 	}
 
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.FilterPanel.Visible {
+		m.FilterPanel = m.FilterPanel.Update(keyMsg, &m.Filters)
+		return m, nil
+	}
+
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		switch keyMsg.Type {
 		case tea.KeyEsc:
@@ -280,7 +310,7 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 			m.SortBy = m.SortBy.Prev()
 			return m, nil
 
-		case tea.KeyCtrlS, tea.KeyCtrlJ, tea.KeyCtrlL:
+		case tea.KeyCtrlS, tea.KeyCtrlJ, tea.KeyCtrlL, tea.KeyCtrlG, tea.KeyCtrlX:
 			for i := range m.DownloadOptions {
 				if m.DownloadOptions[i].KeyBinding == msg.Type {
 					if m.DownloadOptions[i].RequiresFFmpeg && !m.HasFFmpeg {
@@ -293,6 +323,13 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 
 		case tea.KeyCtrlO:
 			utils.OpenURL(types.GithubRepoLink)
+
+		case tea.KeyCtrlF:
+			if !m.ResumeList.Visible {
+				m.Help.Hide()
+				m.FilterPanel.Toggle()
+				return m, nil
+			}
 		}
 	}
 
@@ -318,6 +355,14 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 			switch keyMsg.Type {
 			case tea.KeyDelete, tea.KeyCtrlD:
 				m.ResumeList.DeleteSelected()
+			case tea.KeyCtrlR:
+				if len(m.ResumeList.List.Items()) > 0 {
+					m.ResumeList.Hide()
+					m.Input.SetValue("")
+					cmd = tea.Batch(cmd, func() tea.Msg {
+						return types.ResumeAllMsg{}
+					})
+				}
 			}
 		}
 	}
@@ -360,18 +405,18 @@ func (m SearchModel) handleEnterKey() (SearchModel, tea.Cmd) {
 		}
 		if item := m.ResumeList.SelectedItem(); item != nil {
 			m.ResumeList.Hide()
+			req := item.DownloadRequest()
 			cmd := func() tea.Msg {
-				return types.StartResumeDownloadMsg{
-					URL:      item.URL,
-					FormatID: item.FormatID,
-					Title:    item.Title,
-				}
+				return types.StartResumeDownloadMsg{Request: req}
 			}
 			return m, cmd
 		}
 	}
 
-	query := m.Input.Value()
+	return m.Submit(m.Input.Value())
+}
+
+func (m SearchModel) Submit(query string) (SearchModel, tea.Cmd) {
 	if query == "" {
 		return m, nil
 	}
@@ -382,7 +427,7 @@ func (m SearchModel) handleEnterKey() (SearchModel, tea.Cmd) {
 		return m, cmd
 	}
 
-	m.History.Add(query)
+	m.History.Add(query, m.SortBy, m.Filters)
 	cmd := func() tea.Msg {
 		return types.StartSearchMsg{Query: query}
 	}
@@ -392,15 +437,19 @@ func (m SearchModel) handleEnterKey() (SearchModel, tea.Cmd) {
 func (m *SearchModel) executeSlashCommand(slashCmd, query, args string) tea.Cmd {
 	var cmd tea.Cmd
 	switch slashCmd {
-	case "channel":
+	case "channel", "shorts", "streams":
 		if args == "" {
-			m.Input.SetValue("/channel ")
+			m.Input.SetValue("/" + slashCmd + " ")
 			m.Input.CursorEnd()
 		} else {
-			m.History.Add(query)
+			m.History.Add(query, m.SortBy, m.Filters)
 			channelName := utils.ExtractChannelUsername(args)
+			tab := types.ChannelTabVideos
+			if slashCmd != "channel" {
+				tab = types.ChannelTab(slashCmd)
+			}
 			cmd = func() tea.Msg {
-				return types.StartChannelURLMsg{ChannelName: channelName}
+				return types.StartChannelURLMsg{ChannelName: channelName, Tab: tab}
 			}
 		}
 
@@ -409,16 +458,64 @@ func (m *SearchModel) executeSlashCommand(slashCmd, query, args string) tea.Cmd
 			m.Input.SetValue("/playlist ")
 			m.Input.CursorEnd()
 		} else {
-			m.History.Add(query)
+			m.History.Add(query, m.SortBy, m.Filters)
 			cmd = func() tea.Msg {
 				return types.StartPlaylistURLMsg{Query: args}
 			}
 		}
 
+	case "subscribe", "unsubscribe":
+		if args == "" {
+			m.Input.SetValue("/" + slashCmd + " ")
+			m.Input.CursorEnd()
+		} else {
+			m.Input.SetValue("")
+			unsubscribe := slashCmd == "unsubscribe"
+			cmd = func() tea.Msg {
+				return types.SubscribeMsg{Channel: args, Unsubscribe: unsubscribe}
+			}
+		}
+
+	case "feed":
+		m.Input.SetValue("")
+		cmd = func() tea.Msg {
+			return types.StartFeedMsg{}
+		}
+
+	case "sync":
+		m.Input.SetValue("")
+		cmd = func() tea.Msg {
+			return types.StartSyncMsg{}
+		}
+
 	case "resume":
 		m.ResumeList.Show()
 		m.Input.SetValue("")
 
+	case "downloads":
+		m.Input.SetValue("")
+		cmd = func() tea.Msg {
+			return types.ShowQueueMsg{}
+		}
+
+	case "library":
+		m.Input.SetValue("")
+		cmd = func() tea.Msg {
+			return types.ShowLibraryMsg{}
+		}
+
+	case "history":
+		m.Input.SetValue("")
+		cmd = func() tea.Msg {
+			return types.ShowHistoryMsg{}
+		}
+
+	case "recent":
+		m.Input.SetValue("")
+		cmd = func() tea.Msg {
+			return types.ShowRecentMsg{}
+		}
+
 	case "help":
 		m.Help.Toggle()
 		m.Input.SetValue("")
@@ -462,6 +559,10 @@ func keyTypeToString(key tea.KeyType) string {
 		return "Ctrl+j"
 	case tea.KeyCtrlL:
 		return "Ctrl+l"
+	case tea.KeyCtrlG:
+		return "Ctrl+g"
+	case tea.KeyCtrlX:
+		return "Ctrl+x"
 	default:
 		return ""
 	}
@@ -9,19 +9,24 @@ import (
 )
 
 type StatusKeys struct {
-	Quit   key.Binding
-	Back   key.Binding
-	Enter  key.Binding
-	Pause  key.Binding
-	Cancel key.Binding
-	Tab    key.Binding
-	Help   key.Binding
-	Up     key.Binding
-	Down   key.Binding
-	Select key.Binding
-	Delete key.Binding
-	Next   key.Binding
-	Prev   key.Binding
+	Quit      key.Binding
+	Back      key.Binding
+	Enter     key.Binding
+	Pause     key.Binding
+	Cancel    key.Binding
+	Tab       key.Binding
+	Help      key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Select    key.Binding
+	Delete    key.Binding
+	Next      key.Binding
+	Prev      key.Binding
+	Retry     key.Binding
+	Clear     key.Binding
+	Downloads key.Binding
+	Batch     key.Binding
+	All       key.Binding
 }
 
 func GetStatusKeys(state types.State, helpVisible bool, resumeVisible bool) StatusKeys {
@@ -43,6 +48,10 @@ func GetStatusKeys(state types.State, helpVisible bool, resumeVisible bool) Stat
 				key.WithKeys("delete", "ctrl+d"),
 				key.WithHelp("Del/Ctrl+d", "delete"),
 			)
+			keys.All = key.NewBinding(
+				key.WithKeys("ctrl+r"),
+				key.WithHelp("Ctrl+r", "resume all"),
+			)
 		}
 
 	case types.StateVideoList:
@@ -50,12 +59,130 @@ func GetStatusKeys(state types.State, helpVisible bool, resumeVisible bool) Stat
 			key.WithKeys("esc", "b"),
 			key.WithHelp("Esc/b", "back"),
 		)
+		keys.Downloads = downloadsKey
+		keys.Select = key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "select"),
+		)
+		keys.Batch = key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "download selected"),
+		)
+		keys.All = key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "download all"),
+		)
+		keys.Tab = key.NewBinding(
+			key.WithKeys("o", "O"),
+			key.WithHelp("o/O", "sort/reverse"),
+		)
 
 	case types.StateFormatList:
 		keys.Back = key.NewBinding(
 			key.WithKeys("esc", "b"),
 			key.WithHelp("Esc/b", "back"),
 		)
+		keys.Downloads = downloadsKey
+
+	case types.StateQueue:
+		keys.Back = key.NewBinding(
+			key.WithKeys("esc", "b"),
+			key.WithHelp("Esc/b", "back"),
+		)
+		keys.Enter = key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("Enter", "details"),
+		)
+		keys.Pause = key.NewBinding(
+			key.WithKeys("p", " "),
+			key.WithHelp("p", "pause/resume"),
+		)
+		keys.Cancel = key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "cancel"),
+		)
+		keys.Retry = key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "retry"),
+		)
+		keys.Clear = key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "clear finished"),
+		)
+
+	case types.StateHistory:
+		keys.Back = key.NewBinding(
+			key.WithKeys("esc", "b"),
+			key.WithHelp("Esc/b", "back"),
+		)
+		keys.Enter = key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("Enter", "run again"),
+		)
+		keys.Select = key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pin"),
+		)
+		keys.Delete = key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "delete"),
+		)
+		keys.Next = key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		)
+
+	case types.StateRecent:
+		keys.Back = key.NewBinding(
+			key.WithKeys("esc", "b"),
+			key.WithHelp("Esc/b", "back"),
+		)
+		keys.Enter = key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("Enter", "formats"),
+		)
+		keys.Tab = key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		)
+		keys.Select = key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy URL"),
+		)
+		keys.Delete = key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "delete"),
+		)
+		keys.Next = key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		)
+
+	case types.StateLibrary:
+		keys.Back = key.NewBinding(
+			key.WithKeys("esc", "b"),
+			key.WithHelp("Esc/b", "back"),
+		)
+		keys.Enter = key.NewBinding(
+			key.WithKeys("enter", "o"),
+			key.WithHelp("Enter/o", "open"),
+		)
+		keys.Tab = key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "folder"),
+		)
+		keys.Select = key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy path"),
+		)
+		keys.Delete = key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "delete"),
+		)
+		keys.Clear = key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "forget"),
+		)
 
 	case types.StateDownload:
 		keys.Back = key.NewBinding(
@@ -79,6 +206,11 @@ func GetStatusKeys(state types.State, helpVisible bool, resumeVisible bool) Stat
 	return keys
 }
 
+var downloadsKey = key.NewBinding(
+	key.WithKeys("ctrl+t"),
+	key.WithHelp("Ctrl+t", "downloads"),
+)
+
 func formatKey(binding key.Binding, italic bool) string {
 	help := binding.Help()
 	if help.Desc == "" && help.Key == "" {
@@ -124,6 +256,11 @@ func FormatKeysForStatusBar(keys StatusKeys) string {
 	addKey(keys.Delete)
 	addKey(keys.Next)
 	addKey(keys.Prev)
+	addKey(keys.Retry)
+	addKey(keys.Clear)
+	addKey(keys.Batch)
+	addKey(keys.All)
+	addKey(keys.Downloads)
 
 	return strings.Join(parts, " • ")
 }
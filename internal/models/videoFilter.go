@@ -0,0 +1,262 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xdagiz/xytz/internal/types"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+type videoPredicate func(video types.VideoItem) bool
+
+func parseVideoFilter(term string) ([]videoPredicate, string) {
+	var predicates []videoPredicate
+	var rest []string
+
+	for _, word := range strings.Fields(term) {
+		if predicate, ok := parseVideoPredicate(word); ok {
+			predicates = append(predicates, predicate)
+		} else {
+			rest = append(rest, word)
+		}
+	}
+
+	return predicates, strings.Join(rest, " ")
+}
+
+func parseVideoPredicate(word string) (videoPredicate, bool) {
+	lower := strings.ToLower(word)
+
+	if channel, ok := strings.CutPrefix(lower, "channel:"); ok && channel != "" {
+		return func(video types.VideoItem) bool {
+			return strings.Contains(strings.ToLower(video.Channel), channel)
+		}, true
+	}
+
+	for _, field := range []string{"duration", "dur", "views"} {
+		rest, ok := strings.CutPrefix(lower, field)
+		if !ok {
+			continue
+		}
+
+		op, value := cutOperator(rest)
+		if op == "" {
+			return nil, false
+		}
+
+		var limit float64
+		var err error
+		if field == "views" {
+			limit, err = parseCount(value)
+		} else {
+			limit, err = parseSeconds(value)
+		}
+		if err != nil {
+			return nil, false
+		}
+
+		return func(video types.VideoItem) bool {
+			n := video.Duration
+			if field == "views" {
+				n = video.Views
+			}
+			return compare(n, op, limit)
+		}, true
+	}
+
+	return nil, false
+}
+
+func cutOperator(s string) (string, string) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if value, ok := strings.CutPrefix(s, op); ok {
+			return op, value
+		}
+	}
+
+	return "", s
+}
+
+func compare(n float64, op string, limit float64) bool {
+	switch op {
+	case ">=":
+		return n >= limit
+	case "<=":
+		return n <= limit
+	case ">":
+		return n > limit
+	case "<":
+		return n < limit
+	default:
+		return n == limit
+	}
+}
+
+func parseSeconds(s string) (float64, error) {
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return n * 60, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.Seconds(), nil
+}
+
+func parseCount(s string) (float64, error) {
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		multiplier = 1e3
+	case strings.HasSuffix(s, "m"):
+		multiplier = 1e6
+	case strings.HasSuffix(s, "b"):
+		multiplier = 1e9
+	}
+	if multiplier > 1 {
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return n * multiplier, nil
+}
+
+// The list only hands filter functions the filter values of its items, so
+// the videos behind them are kept here, shared by all copies of the model.
+type videoFilter struct {
+	mutex  sync.Mutex
+	videos []types.VideoItem
+}
+
+func (f *videoFilter) setItems(items []list.Item) {
+	videos := make([]types.VideoItem, len(items))
+	for i, item := range items {
+		switch item := item.(type) {
+		case types.VideoItem:
+			videos[i] = item
+		case types.ChannelItem:
+			videos[i] = types.VideoItem{Channel: item.Name}
+		case types.PlaylistItem:
+			videos[i] = types.VideoItem{Channel: item.Channel}
+		}
+	}
+
+	f.mutex.Lock()
+	f.videos = videos
+	f.mutex.Unlock()
+}
+
+func (f *videoFilter) Filter(term string, targets []string) []list.Rank {
+	predicates, rest := parseVideoFilter(term)
+	if len(predicates) == 0 {
+		return list.DefaultFilter(term, targets)
+	}
+
+	f.mutex.Lock()
+	videos := f.videos
+	f.mutex.Unlock()
+
+	if len(videos) != len(targets) {
+		return list.DefaultFilter(rest, targets)
+	}
+
+	matches := func(index int) bool {
+		for _, predicate := range predicates {
+			if !predicate(videos[index]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var ranks []list.Rank
+	if rest != "" {
+		for _, rank := range list.DefaultFilter(rest, targets) {
+			if matches(rank.Index) {
+				ranks = append(ranks, rank)
+			}
+		}
+		return ranks
+	}
+
+	for i := range targets {
+		if matches(i) {
+			ranks = append(ranks, list.Rank{Index: i})
+		}
+	}
+
+	return ranks
+}
+
+type videoOrder int
+
+const (
+	orderLoaded videoOrder = iota
+	orderDuration
+	orderViews
+	orderTitle
+)
+
+func (o videoOrder) next() videoOrder {
+	return (o + 1) % (orderTitle + 1)
+}
+
+func (o videoOrder) String() string {
+	switch o {
+	case orderDuration:
+		return "duration"
+	case orderViews:
+		return "views"
+	case orderTitle:
+		return "title"
+	default:
+		return ""
+	}
+}
+
+func sortVideos(items []list.Item, order videoOrder, reverse bool) []list.Item {
+	sorted := make([]list.Item, len(items))
+	copy(sorted, items)
+
+	if order == orderLoaded {
+		if reverse {
+			for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+				sorted[i], sorted[j] = sorted[j], sorted[i]
+			}
+		}
+		return sorted
+	}
+
+	less := func(a, b types.VideoItem) bool {
+		switch order {
+		case orderDuration:
+			return a.Duration > b.Duration
+		case orderViews:
+			return a.Views > b.Views
+		default:
+			return strings.ToLower(a.VideoTitle) < strings.ToLower(b.VideoTitle)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := sorted[i].(types.VideoItem)
+		b, _ := sorted[j].(types.VideoItem)
+		if reverse {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+
+	return sorted
+}
@@ -2,12 +2,16 @@ package models
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
+	"github.com/xdagiz/xytz/internal/config"
 	"github.com/xdagiz/xytz/internal/styles"
 	"github.com/xdagiz/xytz/internal/types"
+	"github.com/xdagiz/xytz/internal/utils"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -19,22 +23,92 @@ type VideoListModel struct {
 	CurrentQuery     string
 	IsChannelSearch  bool
 	IsPlaylistSearch bool
+	IsFeed           bool
 	ChannelName      string
+	ChannelTab       types.ChannelTab
 	PlaylistName     string
 	PlaylistURL      string
 	ErrMsg           string
+	Selected         map[string]bool
+	Archived         map[string]bool
+	New              map[string]bool
+	BatchPrompt      bool
+	BatchAudio       bool
+	BatchInput       textinput.Model
+	Collection       CollectionPromptModel
+	SearchURL        string
+	NextStart        int
+	LoadingMore      bool
+	Streaming        bool
+	LastIndex        int
+	Results          []list.Item
+	Order            videoOrder
+	Reverse          bool
+	filter           *videoFilter
+}
+
+const loadMoreThreshold = 5
+
+type videoListDelegate struct {
+	list.DefaultDelegate
+	selected map[string]bool
+	archived map[string]bool
+	isNew    map[string]bool
+}
+
+func (d videoListDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	video, ok := item.(types.VideoItem)
+	if !ok || (!d.selected[video.ID] && !d.archived[video.ID] && !d.isNew[video.ID] && video.Kind == types.VideoKindRegular) {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	dl := d.DefaultDelegate
+	if label := video.Kind.Label(); label != "" {
+		video.Desc = label + " • " + video.Desc
+		if video.Kind == types.VideoKindLive {
+			dl.Styles.NormalDesc = dl.Styles.NormalDesc.Foreground(styles.ErrorColor)
+		}
+	}
+	if d.isNew[video.ID] {
+		dl.Styles.NormalTitle = dl.Styles.NormalTitle.Foreground(styles.InfoColor)
+		video.Desc = "● new • " + video.Desc
+	}
+	if d.archived[video.ID] {
+		dl.Styles.NormalTitle = dl.Styles.NormalTitle.Foreground(styles.MutedColor)
+		dl.Styles.NormalDesc = dl.Styles.NormalDesc.Foreground(styles.MutedColor)
+		video.Desc = "archived • " + video.Desc
+	}
+	if d.selected[video.ID] {
+		dl.Styles.NormalTitle = dl.Styles.NormalTitle.Foreground(styles.SuccessColor)
+		dl.Styles.SelectedTitle = dl.Styles.SelectedTitle.Foreground(styles.SuccessColor).BorderForeground(styles.SuccessColor)
+		video.Desc = "✓ " + video.Desc
+	}
+	dl.Render(w, m, index, video)
 }
 
 func NewVideoListModel() VideoListModel {
-	dl := styles.NewListDelegate()
+	selected := make(map[string]bool)
+	archived := make(map[string]bool)
+	isNew := make(map[string]bool)
+	dl := videoListDelegate{DefaultDelegate: styles.NewListDelegate(), selected: selected, archived: archived, isNew: isNew}
+	filter := &videoFilter{}
 	li := list.New([]list.Item{}, dl, 0, 0)
+	li.Filter = filter.Filter
 	li.SetShowStatusBar(false)
 	li.SetShowTitle(false)
 	li.SetShowHelp(false)
 	li.KeyMap.Quit.SetKeys("q")
+	li.KeyMap.NextPage.SetKeys("right", "l", "pgdown", "d")
 	li.FilterInput.Cursor.Style = li.FilterInput.Cursor.Style.Foreground(styles.MauveColor)
 	li.FilterInput.PromptStyle = li.FilterInput.PromptStyle.Foreground(styles.SecondaryColor)
 
+	ti := textinput.New()
+	ti.Prompt = "❯ "
+	ti.PromptStyle = styles.FormatCustomInputPrompt
+	ti.PlaceholderStyle = ti.PlaceholderStyle.Foreground(styles.MutedColor)
+	ti.TextStyle = ti.TextStyle.Foreground(styles.SecondaryColor)
+
 	return VideoListModel{
 		List:             li,
 		IsChannelSearch:  false,
@@ -43,6 +117,312 @@ func NewVideoListModel() VideoListModel {
 		PlaylistName:     "",
 		PlaylistURL:      "",
 		ErrMsg:           "",
+		Selected:         selected,
+		Archived:         archived,
+		New:              isNew,
+		BatchInput:       ti,
+		Collection:       NewCollectionPromptModel(),
+		filter:           filter,
+	}
+}
+
+func (m *VideoListModel) SetVideos(videos []list.Item) {
+	m.ClearSelection()
+	clear(m.New)
+	m.ReloadArchive()
+	m.Results = videos
+	m.Order = orderLoaded
+	m.Reverse = false
+	m.showResults()
+	m.SetPage("", 0)
+}
+
+func (m *VideoListModel) showResults() tea.Cmd {
+	current := resultKey(m.List.SelectedItem())
+
+	items := sortVideos(m.Results, m.Order, m.Reverse)
+	m.filter.setItems(items)
+	cmd := m.List.SetItems(items)
+
+	if current != "" && m.List.FilterState() == list.Unfiltered {
+		for i, item := range items {
+			if resultKey(item) == current {
+				m.List.Select(i)
+				break
+			}
+		}
+	}
+
+	return cmd
+}
+
+func (m *VideoListModel) cycleOrder(reverse bool) tea.Cmd {
+	if reverse {
+		m.Reverse = !m.Reverse
+	} else {
+		m.Order = m.Order.next()
+		m.Reverse = false
+	}
+
+	return m.showResults()
+}
+
+func (m *VideoListModel) SetPage(searchURL string, next int) {
+	m.SearchURL = searchURL
+	m.NextStart = next
+	m.LoadingMore = false
+	m.Streaming = false
+	m.LastIndex = 0
+}
+
+func (m *VideoListModel) StartStreaming(searchURL string) {
+	m.SetVideos(nil)
+	m.SearchURL = searchURL
+	m.Streaming = true
+}
+
+func (m *VideoListModel) AddStreamed(item list.Item, index int) tea.Cmd {
+	m.LastIndex = index
+	return m.AppendVideos([]list.Item{item})
+}
+
+func resultKey(item list.Item) string {
+	switch item := item.(type) {
+	case types.VideoItem:
+		return "video:" + item.ID
+	case types.ChannelItem:
+		return "channel:" + item.ID
+	case types.PlaylistItem:
+		return "playlist:" + item.ID
+	default:
+		return ""
+	}
+}
+
+func (m *VideoListModel) AppendVideos(videos []list.Item) tea.Cmd {
+	items := m.Results
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		seen[resultKey(item)] = true
+	}
+
+	for _, item := range videos {
+		if key := resultKey(item); key != "" && seen[key] {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	m.Results = items
+	return m.showResults()
+}
+
+func (m VideoListModel) HasMore() bool {
+	return m.SearchURL != "" && m.NextStart > 0
+}
+
+func (m *VideoListModel) LoadMore() tea.Cmd {
+	if !m.HasMore() || m.LoadingMore || m.Streaming || m.List.FilterState() != list.Unfiltered {
+		return nil
+	}
+
+	if len(m.List.Items())-m.List.Index() > loadMoreThreshold {
+		return nil
+	}
+
+	m.LoadingMore = true
+	return func() tea.Msg {
+		return types.LoadMoreMsg{}
+	}
+}
+
+func (m *VideoListModel) SetNew(ids map[string]bool) {
+	clear(m.New)
+	for id := range ids {
+		m.New[id] = true
+	}
+}
+
+func (m *VideoListModel) ReloadArchive() {
+	clear(m.Archived)
+	for id := range utils.LoadArchive() {
+		m.Archived[id] = true
+	}
+}
+
+func (m *VideoListModel) ClearSelection() {
+	clear(m.Selected)
+	m.BatchPrompt = false
+}
+
+func (m VideoListModel) HasPrompt() bool {
+	return m.BatchPrompt || m.Collection.Visible
+}
+
+func (m *VideoListModel) openCollectionPrompt() {
+	switch {
+	case m.IsChannelSearch && m.ChannelName != "":
+		m.Collection.Open(utils.ChannelTabURL(m.ChannelName, m.ChannelTab), "@"+m.ChannelName, true)
+	case m.IsPlaylistSearch && m.PlaylistURL != "":
+		m.Collection.Open(m.PlaylistURL, m.PlaylistName, false)
+	}
+}
+
+func (m VideoListModel) SelectedVideos() []types.VideoItem {
+	var videos []types.VideoItem
+	for _, item := range m.List.Items() {
+		if video, ok := item.(types.VideoItem); ok && m.Selected[video.ID] {
+			videos = append(videos, video)
+		}
+	}
+
+	return videos
+}
+
+func (m VideoListModel) archivedSelected() int {
+	count := 0
+	for id := range m.Selected {
+		if m.Archived[id] {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (m *VideoListModel) toggleSelected() {
+	if video, ok := m.List.SelectedItem().(types.VideoItem); ok {
+		if m.Selected[video.ID] {
+			delete(m.Selected, video.ID)
+		} else {
+			m.Selected[video.ID] = true
+		}
+	}
+}
+
+func (m *VideoListModel) selectItems(items []list.Item) {
+	for _, item := range items {
+		if video, ok := item.(types.VideoItem); ok {
+			m.Selected[video.ID] = true
+		}
+	}
+}
+
+func (m *VideoListModel) invertSelection() {
+	for _, item := range m.List.Items() {
+		if video, ok := item.(types.VideoItem); ok {
+			if m.Selected[video.ID] {
+				delete(m.Selected, video.ID)
+			} else {
+				m.Selected[video.ID] = true
+			}
+		}
+	}
+}
+
+func (m *VideoListModel) openBatchPrompt() {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	m.BatchPrompt = true
+	m.BatchAudio = false
+	m.BatchInput.Placeholder = cfg.DefaultFormat
+	m.BatchInput.SetValue(cfg.DefaultFormat)
+	m.BatchInput.CursorEnd()
+	m.BatchInput.Focus()
+}
+
+func (m VideoListModel) updateBatchPrompt(msg tea.Msg) (VideoListModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc:
+		m.BatchPrompt = false
+		m.BatchInput.Blur()
+		return m, nil
+
+	case tea.KeyTab:
+		m.BatchAudio = !m.BatchAudio
+		if m.BatchAudio {
+			m.BatchInput.SetValue("bestaudio/best")
+		} else {
+			m.BatchInput.SetValue(m.BatchInput.Placeholder)
+		}
+		m.BatchInput.CursorEnd()
+		return m, nil
+
+	case tea.KeyEnter:
+		formatID := strings.TrimSpace(m.BatchInput.Value())
+		var videos []types.VideoItem
+		for _, video := range m.SelectedVideos() {
+			if !m.Archived[video.ID] {
+				videos = append(videos, video)
+			}
+		}
+		if formatID == "" || len(videos) == 0 {
+			return m, nil
+		}
+
+		isAudio := m.BatchAudio
+		m.BatchInput.Blur()
+		m.ClearSelection()
+		return m, func() tea.Msg {
+			return types.StartBatchDownloadMsg{Videos: videos, FormatID: formatID, IsAudio: isAudio}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.BatchInput, cmd = m.BatchInput.Update(msg)
+	return m, cmd
+}
+
+func (m VideoListModel) videoURL(video types.VideoItem) string {
+	if m.IsPlaylistSearch && m.PlaylistURL != "" {
+		playlistID := ""
+		if strings.Contains(m.PlaylistURL, "list=") {
+			parts := strings.Split(m.PlaylistURL, "list=")
+			if len(parts) > 1 {
+				playlistID = parts[1]
+				if idx := strings.Index(playlistID, "&"); idx != -1 {
+					playlistID = playlistID[:idx]
+				}
+			}
+		}
+
+		if playlistID != "" {
+			return fmt.Sprintf("https://www.youtube.com/watch?v=%s&list=%s", video.ID, playlistID)
+		}
+	}
+
+	return "https://www.youtube.com/watch?v=" + video.ID
+}
+
+func (m VideoListModel) openSelected() tea.Cmd {
+	switch item := m.List.SelectedItem().(type) {
+	case types.VideoItem:
+		url := m.videoURL(item)
+		return func() tea.Msg {
+			return types.StartFormatMsg{URL: url, SelectedVideo: item}
+		}
+	case types.ChannelItem:
+		channel := item.Handle
+		if channel == "" {
+			channel = item.ID
+		}
+		return func() tea.Msg {
+			return types.StartChannelURLMsg{ChannelName: channel}
+		}
+	case types.PlaylistItem:
+		return func() tea.Msg {
+			return types.StartPlaylistURLMsg{Query: item.ID, Title: item.PlaylistTitle}
+		}
+	default:
+		return nil
 	}
 }
 
@@ -67,8 +447,14 @@ func (m VideoListModel) View() string {
 		} else {
 			headerText = fmt.Sprintf("An Error Occured: %s", m.ErrMsg)
 		}
+	} else if m.IsFeed {
+		headerText = "Subscriptions feed"
+		if count := len(m.New); count > 0 {
+			headerText += fmt.Sprintf(" (%d new)", count)
+		}
+		headerStyle = styles.SectionHeaderStyle
 	} else if m.IsChannelSearch {
-		headerText = fmt.Sprintf("Videos for channel @%s", m.ChannelName)
+		headerText = fmt.Sprintf("%s for channel @%s", m.ChannelTab.GetDisplayName(), m.ChannelName)
 		headerStyle = styles.SectionHeaderStyle
 	} else if m.IsPlaylistSearch {
 		headerText = fmt.Sprintf("Playlist: %s", m.PlaylistName)
@@ -77,8 +463,46 @@ func (m VideoListModel) View() string {
 		headerText = fmt.Sprintf("Search Results for: %s", m.CurrentQuery)
 		headerStyle = styles.SectionHeaderStyle
 	}
+	if count := len(m.Selected); count > 0 && m.ErrMsg == "" {
+		headerText += styles.SelectionCountStyle.Render(fmt.Sprintf("  (%d selected)", count))
+	}
+	if m.Order != orderLoaded || m.Reverse {
+		order := "sorted by " + m.Order.String()
+		if m.Order == orderLoaded {
+			order = "reversed"
+		} else if m.Reverse {
+			order += " (reversed)"
+		}
+		headerText += styles.MutedStyle.Render("  " + order)
+	}
+	if m.Streaming {
+		headerText += styles.MutedStyle.Render(fmt.Sprintf("  (%d loaded…, c to stop)", len(m.List.Items())))
+	} else if m.LoadingMore {
+		headerText += styles.MutedStyle.Render(fmt.Sprintf("  (%d loaded, loading more…)", len(m.List.Items())))
+	}
 	s.WriteString(headerStyle.Render(headerText))
 	s.WriteRune('\n')
+
+	if m.BatchPrompt {
+		mode := "video"
+		if m.BatchAudio {
+			mode = "audio (mp3)"
+		}
+		text := fmt.Sprintf("Format for %d selected videos, %s (tab to switch)", len(m.Selected), mode)
+		if archived := m.archivedSelected(); archived > 0 {
+			text += fmt.Sprintf(", skipping %d already archived", archived)
+		}
+		s.WriteString(styles.MutedStyle.Render(text))
+		s.WriteRune('\n')
+		s.WriteString(styles.InputStyle.Render(m.BatchInput.View()))
+		s.WriteRune('\n')
+	}
+
+	if m.Collection.Visible {
+		s.WriteString(m.Collection.View())
+		s.WriteRune('\n')
+	}
+
 	s.WriteString(styles.ListContainer.Render(m.List.View()))
 
 	return s.String()
@@ -88,6 +512,8 @@ func (m VideoListModel) HandleResize(w, h int) VideoListModel {
 	m.Width = w
 	m.Height = h
 	m.List.SetSize(w, h-7)
+	m.BatchInput.Width = w - 8
+	m.Collection.SetWidth(w)
 	return m
 }
 
@@ -97,8 +523,74 @@ func (m VideoListModel) Update(msg tea.Msg) (VideoListModel, tea.Cmd) {
 		listCmd tea.Cmd
 	)
 
+	if m.Collection.Visible {
+		m.Collection, cmd = m.Collection.Update(msg)
+		return m, cmd
+	}
+
+	if m.BatchPrompt {
+		return m.updateBatchPrompt(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.List.FilterState() != list.Filtering && m.ErrMsg == "" {
+			switch msg.String() {
+			case " ":
+				m.toggleSelected()
+				m.List.CursorDown()
+				return m, nil
+			case "a":
+				m.selectItems(m.List.Items())
+				return m, nil
+			case "i":
+				m.invertSelection()
+				return m, nil
+			case "s":
+				m.selectItems(m.List.VisibleItems())
+				return m, nil
+			case "x":
+				m.ClearSelection()
+				return m, nil
+			case "D":
+				if len(m.Selected) > 0 {
+					m.openBatchPrompt()
+				}
+				return m, nil
+			case "A":
+				m.openCollectionPrompt()
+				return m, nil
+			case "S":
+				if m.IsChannelSearch && m.ChannelName != "" {
+					channel := m.ChannelName
+					return m, func() tea.Msg {
+						return types.SubscribeMsg{Channel: channel}
+					}
+				}
+				return m, nil
+			case "t":
+				if m.IsChannelSearch && m.ChannelName != "" {
+					channel, tab := m.ChannelName, m.ChannelTab.Next()
+					return m, func() tea.Msg {
+						return types.StartChannelURLMsg{ChannelName: channel, Tab: tab}
+					}
+				}
+				return m, nil
+			case "o":
+				return m, m.cycleOrder(false)
+			case "O":
+				return m, m.cycleOrder(true)
+			case "f":
+				if video, ok := m.List.SelectedItem().(types.VideoItem); ok {
+					url := m.videoURL(video)
+					return m, func() tea.Msg {
+						return types.StartFormatMsg{URL: url, SelectedVideo: video, ChooseFormat: true}
+					}
+				}
+				return m, nil
+			}
+		}
+
 		switch msg.Type {
 		case tea.KeyEnter:
 			if m.List.FilterState() == list.Filtering {
@@ -111,35 +603,12 @@ func (m VideoListModel) Update(msg tea.Msg) (VideoListModel, tea.Cmd) {
 				}
 			} else if len(m.List.Items()) == 0 {
 				return m, nil
-			} else if video, ok := m.List.SelectedItem().(types.VideoItem); ok {
-				var url string
-				if m.IsPlaylistSearch && m.PlaylistURL != "" {
-					playlistID := ""
-					if strings.Contains(m.PlaylistURL, "list=") {
-						parts := strings.Split(m.PlaylistURL, "list=")
-						if len(parts) > 1 {
-							playlistID = parts[1]
-							if idx := strings.Index(playlistID, "&"); idx != -1 {
-								playlistID = playlistID[:idx]
-							}
-						}
-					}
-
-					if playlistID != "" {
-						url = fmt.Sprintf("https://www.youtube.com/watch?v=%s&list=%s", video.ID, playlistID)
-					} else {
-						url = "https://www.youtube.com/watch?v=" + video.ID
-					}
-				} else {
-					url = "https://www.youtube.com/watch?v=" + video.ID
-				}
-				cmd = func() tea.Msg {
-					return types.StartFormatMsg{URL: url, SelectedVideo: video}
-				}
+			} else {
+				cmd = m.openSelected()
 			}
 		}
 	}
 
 	m.List, listCmd = m.List.Update(msg)
-	return m, tea.Batch(cmd, listCmd)
+	return m, tea.Batch(cmd, listCmd, m.LoadMore())
 }
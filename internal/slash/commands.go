@@ -20,18 +20,78 @@ var AllCommands = []Command{
 		Usage:       "/channel <username>",
 		HasArg:      true,
 	},
+	{
+		Name:        "shorts",
+		Description: "List Shorts from a specific channel using @username",
+		Usage:       "/shorts <username>",
+		HasArg:      true,
+	},
+	{
+		Name:        "streams",
+		Description: "List live streams from a specific channel using @username",
+		Usage:       "/streams <username>",
+		HasArg:      true,
+	},
 	{
 		Name:        "playlist",
 		Description: "List videos of a playlist",
 		Usage:       "/playlist <id>",
 		HasArg:      true,
 	},
+	{
+		Name:        "subscribe",
+		Description: "Subscribe to a channel using @username",
+		Usage:       "/subscribe <username>",
+		HasArg:      true,
+	},
+	{
+		Name:        "unsubscribe",
+		Description: "Unsubscribe from a channel",
+		Usage:       "/unsubscribe <username>",
+		HasArg:      true,
+	},
+	{
+		Name:        "feed",
+		Description: "Show the latest uploads of subscribed channels",
+		Usage:       "/feed",
+		HasArg:      false,
+	},
+	{
+		Name:        "sync",
+		Description: "Run the auto-download rules",
+		Usage:       "/sync",
+		HasArg:      false,
+	},
 	{
 		Name:        "resume",
 		Description: "Resume unfinished download",
 		Usage:       "/resume",
 		HasArg:      false,
 	},
+	{
+		Name:        "downloads",
+		Description: "Show the download queue",
+		Usage:       "/downloads",
+		HasArg:      false,
+	},
+	{
+		Name:        "history",
+		Description: "Browse, pin and re-run past searches",
+		Usage:       "/history",
+		HasArg:      false,
+	},
+	{
+		Name:        "recent",
+		Description: "Browse recently opened and downloaded videos",
+		Usage:       "/recent",
+		HasArg:      false,
+	},
+	{
+		Name:        "library",
+		Description: "Browse downloaded videos",
+		Usage:       "/library",
+		HasArg:      false,
+	},
 	{
 		Name:        "help",
 		Description: "Show available commands",
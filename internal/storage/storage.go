@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var ErrNewerVersion = errors.New("file was written by a newer version of xytz")
+
+var errCorrupt = errors.New("corrupt data file")
+
+type Store struct {
+	Path    string
+	Version int
+
+	// Migrate converts data of an older version into the current one.
+	// Version 0 is data without an envelope, as written before the file
+	// was versioned. Without Migrate, older data is decoded as is.
+	Migrate func(version int, data []byte) ([]byte, error)
+}
+
+type envelope struct {
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (s Store) Load(v any) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.load(v)
+}
+
+func (s Store) Save(v any) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.save(v)
+}
+
+func (s Store) Update(v any, fn func() error) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.load(v); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	return s.save(v)
+}
+
+func (s Store) backupPath() string {
+	return s.Path + ".bak"
+}
+
+func (s Store) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(s.Path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", s.Path, err)
+	}
+
+	return func() {
+		if err := unlockFile(f); err != nil {
+			log.Printf("Failed to unlock %s: %v", s.Path, err)
+		}
+		f.Close()
+	}, nil
+}
+
+func (s Store) load(v any) error {
+	err := s.read(s.Path, v)
+	if err == nil || errors.Is(err, ErrNewerVersion) {
+		return err
+	}
+
+	if errors.Is(err, errCorrupt) {
+		aside := fmt.Sprintf("%s.corrupt-%s", s.Path, time.Now().Format("20060102-150405"))
+		log.Printf("Warning: %s is corrupt, moving it to %s", s.Path, aside)
+		if err := os.Rename(s.Path, aside); err != nil {
+			log.Printf("Failed to move corrupt file aside: %v", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	// The data file is missing or corrupt, which also happens when a crash
+	// interrupted a save between its two renames.
+	if err := s.read(s.backupPath(), v); err != nil {
+		if errors.Is(err, ErrNewerVersion) {
+			return err
+		}
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: could not recover %s from its backup: %v", s.Path, err)
+		}
+		return nil
+	}
+
+	log.Printf("Recovered %s from its backup", s.Path)
+	return nil
+}
+
+func (s Store) read(path string, v any) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	version := 0
+	data := raw
+
+	var env envelope
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &env); err != nil || env.Version == 0 {
+			return fmt.Errorf("%w: %s", errCorrupt, path)
+		}
+		version = env.Version
+		data = env.Data
+	}
+
+	if version > s.Version {
+		return fmt.Errorf("%w: %s has version %d, expected at most %d", ErrNewerVersion, path, version, s.Version)
+	}
+
+	if version < s.Version && s.Migrate != nil {
+		data, err = s.Migrate(version, data)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", errCorrupt, path, err)
+		}
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s: %v", errCorrupt, path, err)
+	}
+
+	return nil
+}
+
+func (s Store) save(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(envelope{Version: s.Version, Data: data}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.Path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.Path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(out, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	if err := os.Rename(s.Path, s.backupPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
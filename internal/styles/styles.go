@@ -76,6 +76,12 @@ var (
 	FormatCustomInputStyle     = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), true, false).BorderForeground(MutedColor).MarginTop(1)
 	FormatCustomInputPrompt    = lipgloss.NewStyle().Foreground(PinkColor)
 	FormatCustomHelpStyle      = lipgloss.NewStyle().Foreground(MutedColor).PaddingTop(1)
+
+	QueueTitleStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("#bac2de"))
+	QueueSelectedTitleStyle = lipgloss.NewStyle().Foreground(MauveColor).Bold(true)
+	QueueStateStyle         = lipgloss.NewStyle().Italic(true)
+
+	SelectionCountStyle = lipgloss.NewStyle().Foreground(SuccessColor)
 )
 
 func NewListDelegate() list.DefaultDelegate {
@@ -0,0 +1,39 @@
+package types
+
+type ChannelTab string
+
+const (
+	ChannelTabVideos  ChannelTab = "videos"
+	ChannelTabShorts  ChannelTab = "shorts"
+	ChannelTabStreams ChannelTab = "streams"
+)
+
+var ChannelTabs = []ChannelTab{ChannelTabVideos, ChannelTabShorts, ChannelTabStreams}
+
+func (t ChannelTab) GetDisplayName() string {
+	switch t {
+	case ChannelTabShorts:
+		return "Shorts"
+	case ChannelTabStreams:
+		return "Live streams"
+	default:
+		return "Videos"
+	}
+}
+
+func (t ChannelTab) Next() ChannelTab {
+	if t == "" {
+		t = ChannelTabVideos
+	}
+
+	return cycle(ChannelTabs, t, 1)
+}
+
+func ParseChannelTab(s string) (ChannelTab, error) {
+	tab, err := parseFilter(ChannelTabs, s, "channel tab")
+	if tab == "" {
+		tab = ChannelTabVideos
+	}
+
+	return tab, err
+}
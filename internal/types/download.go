@@ -3,11 +3,11 @@ package types
 import tea "github.com/charmbracelet/bubbletea"
 
 type DownloadOption struct {
-	Name           string
-	KeyBinding     tea.KeyType
-	ConfigField    string
-	RequiresFFmpeg bool
-	Enabled        bool
+	Name           string      `json:"name"`
+	KeyBinding     tea.KeyType `json:"-"`
+	ConfigField    string      `json:"config_field"`
+	RequiresFFmpeg bool        `json:"-"`
+	Enabled        bool        `json:"enabled"`
 }
 
 func DownloadOptions() []DownloadOption {
@@ -30,20 +30,79 @@ func DownloadOptions() []DownloadOption {
 			ConfigField:    "EmbedChapters",
 			RequiresFFmpeg: true,
 		},
+		{
+			Name:        "Live From Start",
+			KeyBinding:  tea.KeyCtrlG,
+			ConfigField: "LiveFromStart",
+		},
+		{
+			Name:        "Wait For Scheduled Streams",
+			KeyBinding:  tea.KeyCtrlX,
+			ConfigField: "WaitForVideo",
+		},
 	}
 }
 
 type DownloadRequest struct {
-	URL      string
-	FormatID string
+	URL      string `json:"url"`
+	FormatID string `json:"format_id"`
+
+	IsAudioTab  bool    `json:"is_audio,omitempty"`
+	ABR         float64 `json:"abr,omitempty"`
+	AudioFormat string  `json:"audio_format,omitempty"`
+
+	Title string `json:"title"`
+
+	Options []DownloadOption `json:"options,omitempty"`
+
+	CookiesFromBrowser string `json:"cookies_from_browser,omitempty"`
+	Cookies            string `json:"cookies,omitempty"`
 
-	IsAudioTab bool
-	ABR        float64
+	OutputPath     string `json:"output_path,omitempty"`
+	OutputTemplate string `json:"output_template,omitempty"`
 
-	Title string
+	Collection    bool   `json:"collection,omitempty"`
+	PlaylistItems string `json:"playlist_items,omitempty"`
+	DateAfter     string `json:"date_after,omitempty"`
+	DateBefore    string `json:"date_before,omitempty"`
+	MatchFilter   string `json:"match_filter,omitempty"`
+}
+
+type DownloadJobState string
+
+const (
+	JobQueued    DownloadJobState = "queued"
+	JobRunning   DownloadJobState = "running"
+	JobPaused    DownloadJobState = "paused"
+	JobFailed    DownloadJobState = "failed"
+	JobCancelled DownloadJobState = "cancelled"
+	JobDone      DownloadJobState = "done"
+)
+
+func (s DownloadJobState) IsActive() bool {
+	return s == JobQueued || s == JobRunning || s == JobPaused
+}
+
+type DownloadPhase string
 
-	Options []DownloadOption
+const (
+	PhaseDownloading        DownloadPhase = "downloading"
+	PhaseMerging            DownloadPhase = "merging"
+	PhaseExtractingAudio    DownloadPhase = "extracting audio"
+	PhaseEmbeddingSubtitles DownloadPhase = "embedding subtitles"
+	PhaseEmbeddingMetadata  DownloadPhase = "embedding metadata"
+	PhaseEmbeddingThumbnail DownloadPhase = "embedding thumbnail"
+	PhaseFixingUp           DownloadPhase = "fixing up"
+)
+
+func (p DownloadPhase) IsPostProcessing() bool {
+	return p != "" && p != PhaseDownloading
+}
 
-	CookiesFromBrowser string
-	Cookies            string
+type DownloadedFile struct {
+	VideoID  string  `json:"id"`
+	Title    string  `json:"title"`
+	Channel  string  `json:"channel"`
+	Duration float64 `json:"duration"`
+	Path     string  `json:"filepath"`
 }
@@ -0,0 +1,333 @@
+package types
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type UploadDate string
+
+const (
+	UploadDateAny   UploadDate = ""
+	UploadDateHour  UploadDate = "hour"
+	UploadDateToday UploadDate = "today"
+	UploadDateWeek  UploadDate = "week"
+	UploadDateMonth UploadDate = "month"
+	UploadDateYear  UploadDate = "year"
+)
+
+var uploadDates = []UploadDate{UploadDateAny, UploadDateHour, UploadDateToday, UploadDateWeek, UploadDateMonth, UploadDateYear}
+
+func (d UploadDate) GetDisplayName() string {
+	switch d {
+	case UploadDateHour:
+		return "Last hour"
+	case UploadDateToday:
+		return "Today"
+	case UploadDateWeek:
+		return "This week"
+	case UploadDateMonth:
+		return "This month"
+	case UploadDateYear:
+		return "This year"
+	default:
+		return "Any time"
+	}
+}
+
+func (d UploadDate) code() int {
+	for i, v := range uploadDates {
+		if v == d {
+			return i
+		}
+	}
+
+	return 0
+}
+
+func (d UploadDate) Next() UploadDate { return cycle(uploadDates, d, 1) }
+func (d UploadDate) Prev() UploadDate { return cycle(uploadDates, d, -1) }
+
+type DurationFilter string
+
+const (
+	DurationAny    DurationFilter = ""
+	DurationShort  DurationFilter = "short"
+	DurationMedium DurationFilter = "medium"
+	DurationLong   DurationFilter = "long"
+)
+
+var durationFilters = []DurationFilter{DurationAny, DurationShort, DurationMedium, DurationLong}
+
+func (d DurationFilter) GetDisplayName() string {
+	switch d {
+	case DurationShort:
+		return "Under 4 minutes"
+	case DurationMedium:
+		return "4-20 minutes"
+	case DurationLong:
+		return "Over 20 minutes"
+	default:
+		return "Any length"
+	}
+}
+
+func (d DurationFilter) code() int {
+	switch d {
+	case DurationShort:
+		return 1
+	case DurationLong:
+		return 2
+	case DurationMedium:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (d DurationFilter) Next() DurationFilter { return cycle(durationFilters, d, 1) }
+func (d DurationFilter) Prev() DurationFilter { return cycle(durationFilters, d, -1) }
+
+type ResultType string
+
+const (
+	ResultTypeAny      ResultType = ""
+	ResultTypeVideo    ResultType = "video"
+	ResultTypeChannel  ResultType = "channel"
+	ResultTypePlaylist ResultType = "playlist"
+)
+
+var resultTypes = []ResultType{ResultTypeAny, ResultTypeVideo, ResultTypeChannel, ResultTypePlaylist}
+
+func (t ResultType) GetDisplayName() string {
+	switch t {
+	case ResultTypeVideo:
+		return "Videos"
+	case ResultTypeChannel:
+		return "Channels"
+	case ResultTypePlaylist:
+		return "Playlists"
+	default:
+		return "Any type"
+	}
+}
+
+func (t ResultType) code() int {
+	for i, v := range resultTypes {
+		if v == t {
+			return i
+		}
+	}
+
+	return 0
+}
+
+func (t ResultType) Next() ResultType { return cycle(resultTypes, t, 1) }
+func (t ResultType) Prev() ResultType { return cycle(resultTypes, t, -1) }
+
+type Feature string
+
+const (
+	FeatureHD        Feature = "hd"
+	Feature4K        Feature = "4k"
+	FeatureSubtitles Feature = "subtitles"
+	FeatureLive      Feature = "live"
+	FeatureCC        Feature = "cc"
+)
+
+var Features = []Feature{FeatureHD, Feature4K, FeatureSubtitles, FeatureLive, FeatureCC}
+
+func (f Feature) GetDisplayName() string {
+	switch f {
+	case FeatureHD:
+		return "HD"
+	case Feature4K:
+		return "4K"
+	case FeatureSubtitles:
+		return "Subtitles"
+	case FeatureLive:
+		return "Live"
+	case FeatureCC:
+		return "Creative Commons"
+	default:
+		return string(f)
+	}
+}
+
+func (f Feature) field() int {
+	switch f {
+	case FeatureHD:
+		return 4
+	case FeatureSubtitles:
+		return 5
+	case FeatureCC:
+		return 6
+	case FeatureLive:
+		return 8
+	case Feature4K:
+		return 14
+	default:
+		return 0
+	}
+}
+
+type SearchFilters struct {
+	UploadDate UploadDate     `json:"upload_date,omitempty"`
+	Duration   DurationFilter `json:"duration,omitempty"`
+	Type       ResultType     `json:"type,omitempty"`
+	Features   []Feature      `json:"features,omitempty"`
+}
+
+func (f SearchFilters) IsZero() bool {
+	return f.UploadDate == "" && f.Duration == "" && f.Type == "" && len(f.Features) == 0
+}
+
+func (f SearchFilters) HasFeature(feature Feature) bool {
+	for _, v := range f.Features {
+		if v == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *SearchFilters) ToggleFeature(feature Feature) {
+	enabled := !f.HasFeature(feature)
+
+	var features []Feature
+	for _, v := range Features {
+		if v == feature && enabled || v != feature && f.HasFeature(v) {
+			features = append(features, v)
+		}
+	}
+
+	f.Features = features
+}
+
+func (f SearchFilters) String() string {
+	var parts []string
+	if f.UploadDate != "" {
+		parts = append(parts, f.UploadDate.GetDisplayName())
+	}
+	if f.Duration != "" {
+		parts = append(parts, f.Duration.GetDisplayName())
+	}
+	if f.Type != "" {
+		parts = append(parts, f.Type.GetDisplayName())
+	}
+	for _, feature := range f.Features {
+		parts = append(parts, feature.GetDisplayName())
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func GetSPParam(sort SortBy, filters SearchFilters) string {
+	var sub []byte
+	if code := filters.UploadDate.code(); code > 0 {
+		sub = append(sub, 1<<3, byte(code))
+	}
+	if code := filters.Type.code(); code > 0 {
+		sub = append(sub, 2<<3, byte(code))
+	}
+	if code := filters.Duration.code(); code > 0 {
+		sub = append(sub, 3<<3, byte(code))
+	}
+	for _, feature := range filters.Features {
+		if field := feature.field(); field > 0 {
+			sub = append(sub, byte(field<<3), 1)
+		}
+	}
+
+	var msg []byte
+	if code := sort.code(); code > 0 {
+		msg = append(msg, 1<<3, byte(code))
+	}
+	if len(sub) > 0 {
+		msg = append(msg, 2<<3|2, byte(len(sub)))
+		msg = append(msg, sub...)
+	}
+
+	if len(msg) == 0 {
+		return ""
+	}
+
+	// The value is escaped twice, as it is embedded in a URL that is passed
+	// on by yt-dlp.
+	return url.QueryEscape(url.QueryEscape(base64.StdEncoding.EncodeToString(msg)))
+}
+
+func ParseUploadDate(s string) (UploadDate, error) {
+	return parseFilter(uploadDates, s, "upload date")
+}
+
+func ParseDurationFilter(s string) (DurationFilter, error) {
+	return parseFilter(durationFilters, s, "duration")
+}
+
+func ParseResultType(s string) (ResultType, error) {
+	return parseFilter(resultTypes, s, "type")
+}
+
+func ParseFeature(s string) (Feature, error) {
+	return parseFilter(Features, s, "feature")
+}
+
+func parseFilter[T ~string](values []T, s, name string) (T, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "any" {
+		return "", nil
+	}
+
+	var names []string
+	for _, v := range values {
+		if string(v) == s {
+			return v, nil
+		}
+		if v != "" {
+			names = append(names, string(v))
+		}
+	}
+
+	return "", fmt.Errorf("unknown %s %q (available: %s)", name, s, strings.Join(names, ", "))
+}
+
+func cycle[T comparable](values []T, current T, dir int) T {
+	for i, v := range values {
+		if v == current {
+			return values[(i+dir+len(values))%len(values)]
+		}
+	}
+
+	return values[0]
+}
+
+func ParseSearchFilters(uploadDate, duration, resultType string, features []string) (SearchFilters, error) {
+	var filters SearchFilters
+	var err error
+
+	if filters.UploadDate, err = ParseUploadDate(uploadDate); err != nil {
+		return filters, err
+	}
+	if filters.Duration, err = ParseDurationFilter(duration); err != nil {
+		return filters, err
+	}
+	if filters.Type, err = ParseResultType(resultType); err != nil {
+		return filters, err
+	}
+
+	for _, name := range features {
+		feature, err := ParseFeature(name)
+		if err != nil {
+			return filters, err
+		}
+		if feature != "" && !filters.HasFeature(feature) {
+			filters.ToggleFeature(feature)
+		}
+	}
+
+	return filters, nil
+}
@@ -9,18 +9,22 @@ const (
 	SortByRating    SortBy = "rating"
 )
 
+var SortOptions = []SortBy{SortByRelevance, SortByDate, SortByViews, SortByRating}
+
 func (s SortBy) GetSPParam() string {
+	return GetSPParam(s, SearchFilters{})
+}
+
+func (s SortBy) code() int {
 	switch s {
-	case SortByRelevance:
-		return ""
+	case SortByRating:
+		return 1
 	case SortByDate:
-		return "CAI%253D"
+		return 2
 	case SortByViews:
-		return "CAM%253D"
-	case SortByRating:
-		return "CAE%253D"
+		return 3
 	default:
-		return ""
+		return 0
 	}
 }
 
@@ -69,6 +73,15 @@ func ParseSortBy(s string) SortBy {
 	}
 }
 
+func ParseSortOption(s string) (SortBy, error) {
+	sortBy, err := parseFilter(SortOptions, s, "sort option")
+	if sortBy == "" {
+		sortBy = SortByRelevance
+	}
+
+	return sortBy, err
+}
+
 func (s SortBy) Prev() SortBy {
 	switch s {
 	case SortByRelevance:
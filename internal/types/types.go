@@ -13,6 +13,10 @@ const (
 	StateFormatList  = "format_list"
 	StateDownload    = "download"
 	StateResumeList  = "resume_list"
+	StateQueue       = "queue"
+	StateLibrary     = "library"
+	StateHistory     = "history"
+	StateRecent      = "recent"
 )
 
 type StartSearchMsg struct {
@@ -22,35 +26,112 @@ type StartSearchMsg struct {
 type StartFormatMsg struct {
 	URL           string
 	SelectedVideo VideoItem
+	ChooseFormat  bool
+	IgnoreLibrary bool
 }
 
 type ProgressMsg struct {
-	Percent     float64
-	Speed       string
-	Eta         string
-	Status      string
-	Destination string
-	FileExtension string
+	JobID           int
+	Percent         float64
+	Speed           string
+	Eta             string
+	Status          string
+	Phase           DownloadPhase
+	Destination     string
+	FileExtension   string
+	ItemIndex       int
+	ItemCount       int
+	VideoID         string
+	DownloadedBytes int64
+	TotalBytes      int64
+	FragmentIndex   int
+	FragmentCount   int
+	Live            bool
+	Elapsed         float64
 }
 
 type VideoItem struct {
-	ID         string
-	VideoTitle string
-	Desc       string
-	Views      float64
-	Duration   float64
-	Channel    string
+	ID         string    `json:"id"`
+	VideoTitle string    `json:"title"`
+	Desc       string    `json:"description,omitempty"`
+	Views      float64   `json:"views,omitempty"`
+	Duration   float64   `json:"duration,omitempty"`
+	Channel    string    `json:"channel,omitempty"`
+	Kind       VideoKind `json:"kind,omitempty"`
+}
+
+type VideoKind string
+
+const (
+	VideoKindRegular  VideoKind = ""
+	VideoKindShort    VideoKind = "short"
+	VideoKindLive     VideoKind = "live"
+	VideoKindUpcoming VideoKind = "upcoming"
+)
+
+func (k VideoKind) Label() string {
+	switch k {
+	case VideoKindShort:
+		return "SHORT"
+	case VideoKindLive:
+		return "LIVE"
+	case VideoKindUpcoming:
+		return "UPCOMING"
+	default:
+		return ""
+	}
+}
+
+func (i VideoItem) IsLive() bool {
+	return i.Kind == VideoKindLive || i.Kind == VideoKindUpcoming
 }
 
 func (i VideoItem) Title() string       { return i.VideoTitle }
 func (i VideoItem) Description() string { return i.Desc }
 func (i VideoItem) FilterValue() string { return i.VideoTitle }
 
+type ChannelItem struct {
+	ID          string
+	Name        string
+	Handle      string
+	Desc        string
+	Subscribers float64
+	VideoCount  float64
+}
+
+func (i ChannelItem) Title() string       { return i.Name }
+func (i ChannelItem) Description() string { return i.Desc }
+func (i ChannelItem) FilterValue() string { return i.Name }
+
+type PlaylistItem struct {
+	ID            string
+	PlaylistTitle string
+	Channel       string
+	Desc          string
+	VideoCount    float64
+}
+
+func (i PlaylistItem) Title() string       { return i.PlaylistTitle }
+func (i PlaylistItem) Description() string { return i.Desc }
+func (i PlaylistItem) FilterValue() string { return i.PlaylistTitle }
+
 type SearchResultMsg struct {
 	Videos []list.Item
 	Err    string
+	URL    string
+	Start  int
+	Next   int
+}
+
+type SearchProgressMsg struct {
+	URL   string
+	Start int
+	Index int
+	Item  list.Item
 }
 
+type LoadMoreMsg struct{}
+
 type FormatItem struct {
 	FormatTitle string
 	FormatValue string
@@ -59,6 +140,7 @@ type FormatItem struct {
 	Resolution  string
 	FormatType  string
 	ABR         float64
+	AudioFormat string
 }
 
 func (i FormatItem) Title() string       { return i.FormatTitle }
@@ -81,39 +163,109 @@ type StartDownloadMsg struct {
 	FormatID        string
 	IsAudioTab      bool
 	ABR             float64
+	AudioFormat     string
 	DownloadOptions []DownloadOption
 }
 
+type StartBatchDownloadMsg struct {
+	Videos   []VideoItem
+	FormatID string
+	IsAudio  bool
+}
+
+type StartCollectionDownloadMsg struct {
+	URL           string
+	Title         string
+	FormatID      string
+	IsAudio       bool
+	PlaylistItems string
+	DateAfter     string
+	DateBefore    string
+}
+
 type DownloadResultMsg struct {
-	Output string
-	Err    string
+	JobID     int
+	Output    string
+	Err       string
+	FilePath  string
+	FilePaths []string
+	Files     []DownloadedFile
 }
 
 type DownloadCompleteMsg struct{}
 
-type PauseDownloadMsg struct{}
+type PauseDownloadMsg struct {
+	JobID int
+}
+
+type ResumeDownloadMsg struct {
+	JobID int
+}
+
+type CancelDownloadMsg struct {
+	JobID int
+}
 
-type ResumeDownloadMsg struct{}
+type ShowQueueMsg struct{}
+
+type ShowLibraryMsg struct{}
+
+type ShowHistoryMsg struct{}
+
+type ShowRecentMsg struct{}
+
+type RunHistoryMsg struct {
+	Query   string
+	SortBy  string
+	Filters SearchFilters
+}
 
-type CancelDownloadMsg struct{}
+type OpenDownloadMsg struct {
+	JobID int
+}
 
 type CancelSearchMsg struct{}
 
 type CancelFormatsMsg struct{}
 
 type StartResumeDownloadMsg struct {
-	URL      string
-	FormatID string
-	Title    string
+	Request DownloadRequest
+}
+
+type ResumeAllMsg struct{}
+
+type FeedResultMsg struct {
+	Videos    []list.Item
+	NewIDs    map[string]bool
+	Err       string
+	Cancelled bool
+}
+
+type StartFeedMsg struct{}
+
+type StartSyncMsg struct{}
+
+type SyncResultMsg struct {
+	Requests []DownloadRequest
+	Channels int
+	Errs     []error
+	NoRules  bool
+}
+
+type SubscribeMsg struct {
+	Channel     string
+	Unsubscribe bool
 }
 
 type StartChannelURLMsg struct {
 	URL         string
 	ChannelName string
+	Tab         ChannelTab
 }
 
 type StartPlaylistURLMsg struct {
 	Query string
+	Title string
 }
 
 type BackFromVideoListMsg struct{}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/xdagiz/xytz/internal/config"
+)
+
+const archiveExtractor = "youtube"
+
+var archiveMutex sync.Mutex
+
+func archivePath() string {
+	cfg, err := config.Load()
+	if err != nil {
+		cfg = config.GetDefault()
+	}
+
+	return cfg.GetDownloadArchivePath()
+}
+
+func LoadArchive() map[string]bool {
+	archiveMutex.Lock()
+	defer archiveMutex.Unlock()
+
+	return loadArchive(archivePath())
+}
+
+func loadArchive(path string) map[string]bool {
+	ids := make(map[string]bool)
+
+	f, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read download archive: %v", err)
+		}
+		return ids
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == archiveExtractor {
+			ids[fields[1]] = true
+		}
+	}
+
+	return ids
+}
+
+func AddToArchive(videoIDs ...string) error {
+	archiveMutex.Lock()
+	defer archiveMutex.Unlock()
+
+	path := archivePath()
+	archived := loadArchive(path)
+
+	var lines strings.Builder
+	for _, id := range videoIDs {
+		if id == "" || archived[id] {
+			continue
+		}
+		archived[id] = true
+		lines.WriteString(archiveExtractor + " " + id + "\n")
+	}
+
+	if lines.Len() == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(lines.String())
+	return err
+}
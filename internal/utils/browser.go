@@ -3,7 +3,10 @@ package utils
 import (
 	"log"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+
+	"github.com/atotto/clipboard"
 )
 
 func OpenURL(url string) {
@@ -27,3 +30,15 @@ func OpenURL(url string) {
 		}
 	}()
 }
+
+func OpenPath(path string) {
+	OpenURL(filepath.Clean(path))
+}
+
+func OpenFolder(path string) {
+	OpenPath(filepath.Dir(path))
+}
+
+func CopyToClipboard(text string) error {
+	return clipboard.WriteAll(text)
+}
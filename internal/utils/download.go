@@ -2,11 +2,15 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -17,130 +21,148 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func StartDownload(dm *DownloadManager, program *tea.Program, title string, req types.DownloadRequest) tea.Cmd {
+func CancelDownload(dq *DownloadQueue, jobID int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		unfinished := UnfinishedDownload{
-			URL:       req.URL,
-			FormatID:  req.FormatID,
-			Title:     title,
-			Timestamp: time.Now(),
-		}
-
-		if err := AddUnfinished(unfinished); err != nil {
-			log.Printf("Failed to add to unfinished list: %v", err)
-		}
-
-		cfg, err := config.Load()
-		if err != nil {
-			log.Printf("Warning: Failed to load config, using defaults: %v", err)
-			cfg = config.GetDefault()
-		}
-
-		downloadPath := cfg.GetDownloadPath()
-
-		cb := req.CookiesFromBrowser
-		c := req.Cookies
-		if cb == "" {
-			cb = cfg.CookiesBrowser
-		}
-		if c == "" {
-			c = cfg.CookiesFile
+		if err := dq.Cancel(jobID); err != nil {
+			log.Printf("Failed to cancel download: %v", err)
 		}
 
-		go doDownload(dm, program, req.URL, req.FormatID, req.IsAudioTab, req.ABR, downloadPath, cfg.YTDLPPath, cb, c, req.Options)
-
 		return nil
 	})
 }
 
-func CancelDownload(dm *DownloadManager) tea.Cmd {
+func RetryDownload(dq *DownloadQueue, jobID int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		if err := dm.Cancel(); err != nil {
-			log.Printf("Failed to cancel download: %v", err)
+		if err := dq.Retry(jobID); err != nil {
+			log.Printf("Failed to retry download: %v", err)
 		}
 
-		return types.CancelDownloadMsg{}
+		return nil
 	})
 }
 
-func doDownload(dm *DownloadManager, program *tea.Program, url, formatID string, isAudioTab bool, abr float64, outputPath, ytDlpPath, cookiesBrowser, cookiesFile string, options []types.DownloadOption) {
-	ctx, cancel := context.WithCancel(context.Background())
-	dm.SetContext(ctx, cancel)
+func RunDownload(dm *DownloadManager, req types.DownloadRequest, onProgress func(types.ProgressMsg)) types.DownloadResultMsg {
+	return runDownload(dm, 0, types.VideoItem{}, req, onProgress)
+}
 
-	if ytDlpPath == "" {
-		ytDlpPath = "yt-dlp"
+func runDownload(dm *DownloadManager, jobID int, video types.VideoItem, req types.DownloadRequest, onProgress func(types.ProgressMsg)) types.DownloadResultMsg {
+	startedAt := time.Now()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Warning: Failed to load config, using defaults: %v", err)
+		cfg = config.GetDefault()
 	}
 
-	if url == "" {
-		log.Printf("download error: empty URL provided")
-		program.Send(types.DownloadResultMsg{Err: "Download error: empty URL provided"})
-		return
+	if req.OutputPath == "" {
+		req.OutputPath = cfg.GetDownloadPath()
+	} else {
+		req.OutputPath = cfg.ExpandPath(req.OutputPath)
+	}
+
+	if req.OutputTemplate == "" {
+		req.OutputTemplate = outputTemplate(cfg.OutputTemplates, req)
+	}
+
+	if req.CookiesFromBrowser == "" && req.Cookies == "" {
+		req.CookiesFromBrowser = cfg.CookiesBrowser
+		if req.CookiesFromBrowser == "" {
+			req.Cookies = cfg.CookiesFile
+		}
+	}
+
+	unfinished := UnfinishedDownload{
+		URL:       req.URL,
+		FormatID:  req.FormatID,
+		Title:     req.Title,
+		Timestamp: time.Now(),
+		Request:   &req,
 	}
 
-	isPlaylist := strings.Contains(url, "/playlist?list=") || strings.Contains(url, "&list=")
+	if err := AddUnfinished(unfinished); err != nil {
+		log.Printf("Failed to add to unfinished list: %v", err)
+	}
 
 	var (
-		args          []string
-		fileExtension string
+		partialPath  string
+		partialMutex sync.Mutex
 	)
+	trackProgress := func(msg types.ProgressMsg) {
+		partialMutex.Lock()
+		if msg.Destination != "" && msg.Phase == types.PhaseDownloading && msg.Destination != partialPath {
+			partialPath = msg.Destination
+			if err := SetUnfinishedPartialPath(req.URL, partialPath+".part"); err != nil {
+				log.Printf("Failed to record partial download path: %v", err)
+			}
+		}
+		partialMutex.Unlock()
 
-	if isAudioTab {
-		audioQuality := fmt.Sprintf("%dK", int(abr))
-		fileExtension = ".mp3"
-		args = []string{
-			"-f",
-			formatID,
-			"-o",
-			filepath.Join(outputPath, "%(artist)s - %(title)s.%(ext)s"),
-			"--restrict-filenames",
-			"--embed-thumbnail",
-			"-x",
-			"--audio-format",
-			"mp3",
-			"--audio-quality",
-			audioQuality,
-			"--add-metadata",
-			"--metadata-from-title",
-			"%(artist)s - %(title)s",
-			"--newline",
-			"-R",
-			"infinite",
-			url,
+		onProgress(msg)
+	}
+
+	template := filepath.Join(req.OutputPath, req.OutputTemplate)
+
+	// Only collections consult the archive, so that a single video can still
+	// be downloaded again on request.
+	archiveFile := ""
+	if req.Collection || isPlaylistURL(req.URL) {
+		archiveFile = cfg.GetDownloadArchivePath()
+	}
+
+	result := doDownload(dm, req, template, archiveFile, cfg.YTDLPPath, req.CookiesFromBrowser, req.Cookies, trackProgress)
+	result.JobID = jobID
+
+	if result.Err == "" && len(result.Files) > 0 {
+		entries := libraryEntries(video, req, result.Files, startedAt)
+		if err := AddToLibrary(entries...); err != nil {
+			log.Printf("Failed to add download to library: %v", err)
 		}
-	} else {
-		fileExtension = ".mp4"
-		args = []string{
-			"-f",
-			formatID,
-			"--newline",
-			"-R",
-			"infinite",
-			"-o",
-			filepath.Join(outputPath, "%(title)s.%(ext)s"),
-			url,
+
+		videoIDs := make([]string, len(entries))
+		for i, entry := range entries {
+			videoIDs[i] = entry.VideoID
+		}
+		if err := AddToArchive(videoIDs...); err != nil {
+			log.Printf("Failed to add download to archive: %v", err)
+		}
+
+		if err := AddRecent(RecentDownloaded, recentVideos(video, entries)...); err != nil {
+			log.Printf("Failed to add download to recent videos: %v", err)
 		}
 	}
 
-	if !isPlaylist {
-		args = append([]string{"--no-playlist"}, args...)
+	return result
+}
+
+func doDownload(dm *DownloadManager, req types.DownloadRequest, outputTemplate, archiveFile, ytDlpPath, cookiesBrowser, cookiesFile string, onProgress func(types.ProgressMsg)) types.DownloadResultMsg {
+	ctx, cancel := context.WithCancel(context.Background())
+	dm.SetContext(ctx, cancel)
+
+	if ytDlpPath == "" {
+		ytDlpPath = "yt-dlp"
 	}
 
-	if cookiesBrowser != "" {
-		args = append([]string{"--cookies-from-browser", cookiesBrowser}, args...)
-	} else if cookiesFile != "" {
-		args = append([]string{"--cookies", cookiesFile}, args...)
+	if req.URL == "" {
+		log.Printf("download error: empty URL provided")
+		return types.DownloadResultMsg{Err: "Download error: empty URL provided"}
 	}
 
-	for _, opt := range options {
-		if opt.Enabled {
-			switch opt.ConfigField {
-			case "EmbedSubtitles":
-				args = append(args, "--embed-subs")
-			case "EmbedMetadata":
-				args = append(args, "--embed-metadata")
-			case "EmbedChapters":
-				args = append(args, "--embed-chapters")
-			}
+	// Match filters only compare absolute dates, not ones like today-2weeks.
+	breakOnOlder := absoluteDatePattern.MatchString(req.DateAfter) && strings.Contains(req.URL, "/videos") && supportsBreakMatchFilters(ytDlpPath)
+	args, fileExtension := buildDownloadArgs(req, outputTemplate, archiveFile, cookiesBrowser, cookiesFile, breakOnOlder)
+	if supportsProgressTemplate(ytDlpPath) {
+		args = append(ProgressTemplateArgs(), args...)
+	}
+
+	var pathsFile string
+	if supportsPrintToFile(ytDlpPath) {
+		if f, err := os.CreateTemp("", "xytz-paths-*.txt"); err != nil {
+			log.Printf("Failed to create file paths file: %v", err)
+		} else {
+			pathsFile = f.Name()
+			f.Close()
+			defer os.Remove(pathsFile)
+			args = append([]string{"--print-to-file", "after_move:%(.{id,title,channel,duration,filepath})j", pathsFile, "--no-simulate"}, args...)
 		}
 	}
 
@@ -153,8 +175,7 @@ func doDownload(dm *DownloadManager, program *tea.Program, url, formatID string,
 	if err != nil {
 		log.Printf("pipe error: %v", err)
 		errMsg := fmt.Sprintf("pipe error: %v", err)
-		program.Send(types.DownloadResultMsg{Err: errMsg})
-		return
+		return types.DownloadResultMsg{Err: errMsg}
 	}
 
 	stderr, err2 := cmd.StderrPipe()
@@ -162,8 +183,7 @@ func doDownload(dm *DownloadManager, program *tea.Program, url, formatID string,
 		stdout.Close()
 		log.Printf("stderr pipe error: %v", err2)
 		errMsg := fmt.Sprintf("stderr pipe error: %v", err2)
-		program.Send(types.DownloadResultMsg{Err: errMsg})
-		return
+		return types.DownloadResultMsg{Err: errMsg}
 	}
 
 	if err := cmd.Start(); err != nil {
@@ -171,16 +191,16 @@ func doDownload(dm *DownloadManager, program *tea.Program, url, formatID string,
 		stderr.Close()
 		log.Printf("start error: %v", err)
 		errMsg := fmt.Sprintf("start error: %v", err)
-		program.Send(types.DownloadResultMsg{Err: errMsg})
-		return
+		return types.DownloadResultMsg{Err: errMsg}
 	}
 
 	parser := NewProgressParser()
 	var wg sync.WaitGroup
 	readPipe := func(pipe io.Reader) {
 		defer wg.Done()
-		parser.ReadPipe(pipe, func(percent float64, speed, eta, status, destination string) {
-			program.Send(types.ProgressMsg{Percent: percent, Speed: speed, Eta: eta, Status: status, Destination: destination, FileExtension: fileExtension})
+		parser.ReadPipe(pipe, func(msg types.ProgressMsg) {
+			msg.FileExtension = fileExtension
+			onProgress(msg)
 		})
 	}
 
@@ -205,18 +225,243 @@ func doDownload(dm *DownloadManager, program *tea.Program, url, formatID string,
 	dm.Clear()
 
 	if ctx.Err() == context.Canceled {
-		program.Send(types.DownloadResultMsg{Err: "Download cancelled"})
-		return
+		return types.DownloadResultMsg{Err: "Download cancelled"}
+	}
+
+	// yt-dlp exits with 101 once --break-match-filters reaches an upload
+	// older than --dateafter, which is how such a run normally ends.
+	var exitErr *exec.ExitError
+	if breakOnOlder && errors.As(err, &exitErr) && exitErr.ExitCode() == breakExitCode {
+		err = nil
 	}
 
 	if err != nil {
 		errMsg := fmt.Sprintf("Download error: %v", err)
-		program.Send(types.DownloadResultMsg{Err: errMsg})
+		return types.DownloadResultMsg{Err: errMsg}
+	}
+
+	if err := RemoveUnfinished(req.URL); err != nil {
+		log.Printf("Failed to remove from unfinished list: %v", err)
+	}
+
+	var files []types.DownloadedFile
+	if pathsFile != "" {
+		files = readDownloadedFiles(pathsFile)
+	}
+	if len(files) == 0 {
+		if finalPath := parser.FinalPath(); finalPath != "" {
+			files = []types.DownloadedFile{{Path: finalPath}}
+		}
+	}
+
+	result := types.DownloadResultMsg{Output: "Download complete", Files: files}
+	for _, file := range files {
+		result.FilePaths = append(result.FilePaths, file.Path)
+	}
+	if len(files) > 0 {
+		result.FilePath = files[len(files)-1].Path
+	}
+
+	return result
+}
+
+const (
+	progressTemplateSince  = "2021.10.09"
+	printToFileSince       = "2022.04.08"
+	breakMatchFiltersSince = "2023.06.21"
+)
+
+const breakExitCode = 101
+
+var absoluteDatePattern = regexp.MustCompile(`^\d{8}$`)
+
+var (
+	ytDlpVersions = make(map[string]string)
+	ytDlpMutex    sync.Mutex
+)
+
+func ytDlpVersion(ytDlpPath string) string {
+	ytDlpMutex.Lock()
+	defer ytDlpMutex.Unlock()
+
+	if version, ok := ytDlpVersions[ytDlpPath]; ok {
+		return version
+	}
+
+	output, err := exec.Command(ytDlpPath, "--version").Output()
+	if err != nil {
+		log.Printf("Failed to get yt-dlp version: %v", err)
+		return ""
+	}
+
+	version := strings.TrimSpace(string(output))
+	ytDlpVersions[ytDlpPath] = version
+	return version
+}
+
+func supportsProgressTemplate(ytDlpPath string) bool {
+	version := ytDlpVersion(ytDlpPath)
+	if version < progressTemplateSince {
+		log.Printf("yt-dlp %q does not support --progress-template, using the fallback progress parser", version)
+		return false
+	}
+
+	return true
+}
+
+func supportsPrintToFile(ytDlpPath string) bool {
+	return ytDlpVersion(ytDlpPath) >= printToFileSince
+}
+
+func supportsBreakMatchFilters(ytDlpPath string) bool {
+	return ytDlpVersion(ytDlpPath) >= breakMatchFiltersSince
+}
+
+func readDownloadedFiles(name string) []types.DownloadedFile {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		log.Printf("Failed to read downloaded files: %v", err)
+		return nil
+	}
+
+	var files []types.DownloadedFile
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		var file types.DownloadedFile
+		if err := json.Unmarshal([]byte(line), &file); err != nil {
+			log.Printf("Failed to decode downloaded file %q: %v", line, err)
+			continue
+		}
+
+		if file.Path != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+func isPlaylistURL(url string) bool {
+	return strings.Contains(url, "/playlist?list=") || strings.Contains(url, "&list=")
+}
+
+func isChannelURL(url string) bool {
+	return strings.Contains(url, "/@") || strings.Contains(url, "/channel/") || strings.Contains(url, "/c/") || strings.Contains(url, "/user/")
+}
+
+func outputTemplate(templates config.OutputTemplates, req types.DownloadRequest) string {
+	switch {
+	case req.Collection && isChannelURL(req.URL):
+		return templates.Channel
+	case req.Collection || isPlaylistURL(req.URL):
+		return templates.Playlist
+	case req.IsAudioTab:
+		return templates.Audio
+	default:
+		return templates.Video
+	}
+}
+
+const waitForVideoInterval = "30-300"
+
+func buildDownloadArgs(req types.DownloadRequest, outputTemplate, archiveFile, cookiesBrowser, cookiesFile string, breakOnOlder bool) (args []string, fileExtension string) {
+	url := req.URL
+	formatID := req.FormatID
+	isPlaylist := req.Collection || isPlaylistURL(url)
+
+	if req.IsAudioTab {
+		audioQuality := fmt.Sprintf("%dK", int(req.ABR))
+		audioFormat := req.AudioFormat
+		if audioFormat == "" {
+			audioFormat = "mp3"
+		}
+		fileExtension = "." + audioFormat
+		args = []string{
+			"-f",
+			formatID,
+			"-o",
+			outputTemplate,
+			"--restrict-filenames",
+			"--embed-thumbnail",
+			"-x",
+			"--audio-format",
+			audioFormat,
+			"--audio-quality",
+			audioQuality,
+			"--add-metadata",
+			"--metadata-from-title",
+			"%(artist)s - %(title)s",
+			"--newline",
+			"-R",
+			"infinite",
+			url,
+		}
 	} else {
-		if err := RemoveUnfinished(url); err != nil {
-			log.Printf("Failed to remove from unfinished list: %v", err)
+		fileExtension = ".mp4"
+		args = []string{
+			"-f",
+			formatID,
+			"--newline",
+			"-R",
+			"infinite",
+			"-o",
+			outputTemplate,
+			url,
 		}
+	}
 
-		program.Send(types.DownloadResultMsg{Output: "Download complete"})
+	if !isPlaylist {
+		args = append([]string{"--no-playlist"}, args...)
+	}
+
+	if req.PlaylistItems != "" {
+		args = append([]string{"--playlist-items", req.PlaylistItems}, args...)
 	}
+
+	if req.DateAfter != "" {
+		args = append([]string{"--dateafter", req.DateAfter}, args...)
+		if breakOnOlder {
+			args = append([]string{"--break-match-filters", "upload_date>=" + req.DateAfter}, args...)
+		}
+	}
+
+	if req.DateBefore != "" {
+		args = append([]string{"--datebefore", req.DateBefore}, args...)
+	}
+
+	if req.MatchFilter != "" {
+		args = append([]string{"--match-filter", req.MatchFilter}, args...)
+	}
+
+	if archiveFile != "" {
+		args = append([]string{"--download-archive", archiveFile}, args...)
+	}
+
+	if cookiesBrowser != "" {
+		args = append([]string{"--cookies-from-browser", cookiesBrowser}, args...)
+	} else if cookiesFile != "" {
+		args = append([]string{"--cookies", cookiesFile}, args...)
+	}
+
+	for _, opt := range req.Options {
+		if opt.Enabled {
+			switch opt.ConfigField {
+			case "EmbedSubtitles":
+				args = append(args, "--embed-subs")
+			case "EmbedMetadata":
+				args = append(args, "--embed-metadata")
+			case "EmbedChapters":
+				args = append(args, "--embed-chapters")
+			case "LiveFromStart":
+				args = append(args, "--live-from-start")
+			case "WaitForVideo":
+				args = append(args, "--wait-for-video", waitForVideoInterval)
+			}
+		}
+	}
+
+	return args, fileExtension
 }
@@ -13,6 +13,7 @@ type DownloadManager struct {
 	cancel   context.CancelFunc
 	mutex    sync.Mutex
 	isPaused bool
+	canceled bool
 }
 
 func NewDownloadManager() *DownloadManager {
@@ -39,6 +40,10 @@ func (dm *DownloadManager) SetContext(ctx context.Context, cancel context.Cancel
 
 	dm.ctx = ctx
 	dm.cancel = cancel
+
+	if dm.canceled {
+		cancel()
+	}
 }
 
 func (dm *DownloadManager) GetContext() (context.Context, context.CancelFunc) {
@@ -76,6 +81,7 @@ func (dm *DownloadManager) Cancel() error {
 	dm.mutex.Lock()
 	defer dm.mutex.Unlock()
 
+	dm.canceled = true
 	if dm.cancel != nil {
 		dm.cancel()
 	}
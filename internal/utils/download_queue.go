@@ -0,0 +1,340 @@
+package utils
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/xdagiz/xytz/internal/types"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type DownloadJob struct {
+	ID          int
+	Video       types.VideoItem
+	Request     types.DownloadRequest
+	State       types.DownloadJobState
+	Percent     float64
+	Speed       string
+	Eta         string
+	Status      string
+	Phase       types.DownloadPhase
+	Destination string
+	ItemIndex   int
+	ItemCount   int
+	Live        bool
+	Elapsed     float64
+	FilePaths   []string
+	Err         string
+	AddedAt     time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	manager     *DownloadManager
+}
+
+func (j DownloadJob) Title() string {
+	if j.Request.Title != "" {
+		return j.Request.Title
+	}
+
+	if j.Video.VideoTitle != "" {
+		return j.Video.VideoTitle
+	}
+
+	return j.Request.URL
+}
+
+type DownloadQueue struct {
+	jobs          []*DownloadJob
+	nextID        int
+	maxConcurrent int
+	program       *tea.Program
+	mutex         sync.Mutex
+}
+
+func NewDownloadQueue(maxConcurrent int) *DownloadQueue {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &DownloadQueue{maxConcurrent: maxConcurrent}
+}
+
+func (dq *DownloadQueue) SetProgram(program *tea.Program) {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	dq.program = program
+}
+
+func (dq *DownloadQueue) send(msg tea.Msg) {
+	dq.mutex.Lock()
+	program := dq.program
+	dq.mutex.Unlock()
+
+	if program != nil {
+		program.Send(msg)
+	}
+}
+
+func (dq *DownloadQueue) Enqueue(video types.VideoItem, req types.DownloadRequest) int {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	if req.Title == "" {
+		req.Title = video.VideoTitle
+	}
+
+	dq.nextID++
+	job := &DownloadJob{
+		ID:      dq.nextID,
+		Video:   video,
+		Request: req,
+		State:   types.JobQueued,
+		AddedAt: time.Now(),
+	}
+
+	dq.jobs = append(dq.jobs, job)
+	dq.schedule()
+
+	return job.ID
+}
+
+func (dq *DownloadQueue) Jobs() []DownloadJob {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	jobs := make([]DownloadJob, len(dq.jobs))
+	for i, job := range dq.jobs {
+		jobs[i] = dq.snapshot(job)
+	}
+
+	return jobs
+}
+
+func (dq *DownloadQueue) Job(id int) (DownloadJob, bool) {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	job := dq.find(id)
+	if job == nil {
+		return DownloadJob{}, false
+	}
+
+	return dq.snapshot(job), true
+}
+
+func (dq *DownloadQueue) Manager(id int) *DownloadManager {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	job := dq.find(id)
+	if job == nil {
+		return nil
+	}
+
+	return job.manager
+}
+
+func (dq *DownloadQueue) ActiveCount() int {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	count := 0
+	for _, job := range dq.jobs {
+		if job.State.IsActive() {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (dq *DownloadQueue) Cancel(id int) error {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	job := dq.find(id)
+	if job == nil {
+		return fmt.Errorf("download %d not found", id)
+	}
+
+	switch job.State {
+	case types.JobQueued:
+		job.State = types.JobCancelled
+		job.FinishedAt = time.Now()
+		return nil
+
+	case types.JobRunning:
+		job.State = types.JobCancelled
+		if job.manager != nil {
+			return job.manager.Cancel()
+		}
+	}
+
+	return nil
+}
+
+func (dq *DownloadQueue) Retry(id int) error {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	job := dq.find(id)
+	if job == nil {
+		return fmt.Errorf("download %d not found", id)
+	}
+
+	if job.State != types.JobFailed && job.State != types.JobCancelled {
+		return fmt.Errorf("download %d is %s", id, job.State)
+	}
+
+	job.State = types.JobQueued
+	job.Percent = 0
+	job.Speed = ""
+	job.Eta = ""
+	job.Status = ""
+	job.Phase = ""
+	job.ItemIndex = 0
+	job.ItemCount = 0
+	job.Live = false
+	job.Elapsed = 0
+	job.FilePaths = nil
+	job.Err = ""
+	job.FinishedAt = time.Time{}
+	job.manager = nil
+	dq.schedule()
+
+	return nil
+}
+
+func (dq *DownloadQueue) ClearFinished() {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	var jobs []*DownloadJob
+	for _, job := range dq.jobs {
+		if job.State.IsActive() {
+			jobs = append(jobs, job)
+		}
+	}
+
+	dq.jobs = jobs
+}
+
+func (dq *DownloadQueue) CancelAll() {
+	dq.mutex.Lock()
+	defer dq.mutex.Unlock()
+
+	for _, job := range dq.jobs {
+		if !job.State.IsActive() {
+			continue
+		}
+
+		wasRunning := job.State == types.JobRunning
+		job.State = types.JobCancelled
+		job.FinishedAt = time.Now()
+		if wasRunning && job.manager != nil {
+			if err := job.manager.Cancel(); err != nil {
+				log.Printf("Failed to cancel download %d: %v", job.ID, err)
+			}
+		}
+	}
+}
+
+func (dq *DownloadQueue) find(id int) *DownloadJob {
+	for _, job := range dq.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+
+	return nil
+}
+
+func (dq *DownloadQueue) snapshot(job *DownloadJob) DownloadJob {
+	s := *job
+	if s.State == types.JobRunning && s.manager != nil && s.manager.IsPaused() {
+		s.State = types.JobPaused
+	}
+
+	return s
+}
+
+func (dq *DownloadQueue) schedule() {
+	running := 0
+	for _, job := range dq.jobs {
+		if job.State == types.JobRunning {
+			running++
+		}
+	}
+
+	for _, job := range dq.jobs {
+		if running >= dq.maxConcurrent {
+			return
+		}
+
+		if job.State != types.JobQueued {
+			continue
+		}
+
+		job.State = types.JobRunning
+		job.StartedAt = time.Now()
+		job.manager = NewDownloadManager()
+		running++
+
+		go dq.run(job, job.manager, job.Request)
+	}
+}
+
+func (dq *DownloadQueue) run(job *DownloadJob, dm *DownloadManager, req types.DownloadRequest) {
+	result := runDownload(dm, job.ID, job.Video, req, func(msg types.ProgressMsg) {
+		msg.JobID = job.ID
+
+		dq.mutex.Lock()
+		if job.manager != dm {
+			dq.mutex.Unlock()
+			return
+		}
+		job.Percent = msg.Percent
+		job.Speed = msg.Speed
+		job.Eta = msg.Eta
+		job.Status = msg.Status
+		job.Phase = msg.Phase
+		job.Live = msg.Live
+		job.Elapsed = msg.Elapsed
+		if msg.ItemCount > 0 {
+			job.ItemIndex = msg.ItemIndex
+			job.ItemCount = msg.ItemCount
+		}
+		if msg.Destination != "" {
+			job.Destination = msg.Destination
+		}
+		dq.mutex.Unlock()
+
+		dq.send(msg)
+	})
+
+	dq.mutex.Lock()
+	if job.manager != dm {
+		dq.mutex.Unlock()
+		return
+	}
+	job.FinishedAt = time.Now()
+	switch {
+	case job.State == types.JobCancelled:
+		job.Err = "Download cancelled"
+	case result.Err != "":
+		job.State = types.JobFailed
+		job.Err = result.Err
+	default:
+		job.State = types.JobDone
+		job.Percent = 100
+		job.FilePaths = result.FilePaths
+	}
+	dq.schedule()
+	dq.mutex.Unlock()
+
+	dq.send(result)
+}
@@ -11,8 +11,13 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func PauseDownload(dm *DownloadManager) tea.Cmd {
+func PauseDownload(dq *DownloadQueue, jobID int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
+		dm := dq.Manager(jobID)
+		if dm == nil {
+			return types.PauseDownloadMsg{JobID: jobID}
+		}
+
 		cmd := dm.GetCmd()
 		if cmd != nil && cmd.Process != nil && !dm.IsPaused() {
 			dm.SetPaused(true)
@@ -21,12 +26,17 @@ func PauseDownload(dm *DownloadManager) tea.Cmd {
 			}
 		}
 
-		return types.PauseDownloadMsg{}
+		return types.PauseDownloadMsg{JobID: jobID}
 	})
 }
 
-func ResumeDownload(dm *DownloadManager) tea.Cmd {
+func ResumeDownload(dq *DownloadQueue, jobID int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
+		dm := dq.Manager(jobID)
+		if dm == nil {
+			return types.ResumeDownloadMsg{JobID: jobID}
+		}
+
 		cmd := dm.GetCmd()
 		if cmd != nil && cmd.Process != nil && dm.IsPaused() {
 			dm.SetPaused(false)
@@ -35,6 +45,6 @@ func ResumeDownload(dm *DownloadManager) tea.Cmd {
 			}
 		}
 
-		return types.ResumeDownloadMsg{}
+		return types.ResumeDownloadMsg{JobID: jobID}
 	})
 }
@@ -10,24 +10,34 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func PauseDownload(dm *DownloadManager) tea.Cmd {
+func PauseDownload(dq *DownloadQueue, jobID int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
+		dm := dq.Manager(jobID)
+		if dm == nil {
+			return types.PauseDownloadMsg{JobID: jobID}
+		}
+
 		cmd := dm.GetCmd()
 		if cmd != nil && cmd.Process != nil && !dm.IsPaused() {
 			log.Print("pause not supported on windows")
 		}
 
-		return types.PauseDownloadMsg{}
+		return types.PauseDownloadMsg{JobID: jobID}
 	})
 }
 
-func ResumeDownload(dm *DownloadManager) tea.Cmd {
+func ResumeDownload(dq *DownloadQueue, jobID int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
+		dm := dq.Manager(jobID)
+		if dm == nil {
+			return types.ResumeDownloadMsg{JobID: jobID}
+		}
+
 		cmd := dm.GetCmd()
 		if cmd != nil && cmd.Process != nil && dm.IsPaused() {
 			log.Print("resume not supported on windows")
 		}
 
-		return types.ResumeDownloadMsg{}
+		return types.ResumeDownloadMsg{JobID: jobID}
 	})
 }
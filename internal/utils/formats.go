@@ -393,22 +393,20 @@ func extractVideoInfo(data map[string]any) types.VideoItem {
 		duration = parseFloat(d)
 	}
 
-	viewsStr := FormatNumber(viewCount)
-	durationStr := FormatDuration(duration)
-
 	if len(channel) > 30 {
 		channel = channel[:27] + "..."
 	}
 
-	desc := fmt.Sprintf("%s • %s views • %s", durationStr, viewsStr, channel)
+	kind := videoKind(data)
 
 	return types.VideoItem{
 		ID:         videoID,
 		VideoTitle: title,
-		Desc:       desc,
+		Desc:       videoDesc(data, kind, duration, viewCount, channel),
 		Views:      viewCount,
 		Duration:   duration,
 		Channel:    channel,
+		Kind:       kind,
 	}
 }
 
@@ -1,15 +1,90 @@
 package utils
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
-	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/xdagiz/xytz/internal/paths"
+	"github.com/xdagiz/xytz/internal/storage"
+	"github.com/xdagiz/xytz/internal/types"
 )
 
-const HistoryFileName = "history"
+const (
+	HistoryFileName = "history"
+	historyVersion  = 2
+	maxHistory      = 1000
+)
+
+type HistoryKind string
+
+const (
+	HistorySearch   HistoryKind = "search"
+	HistoryChannel  HistoryKind = "channel"
+	HistoryPlaylist HistoryKind = "playlist"
+	HistoryURL      HistoryKind = "url"
+)
+
+type HistoryEntry struct {
+	Query       string              `json:"query"`
+	Kind        HistoryKind         `json:"kind"`
+	SortBy      string              `json:"sort_by,omitempty"`
+	Filters     types.SearchFilters `json:"filters,omitzero"`
+	Timestamp   time.Time           `json:"timestamp"`
+	ResultCount int                 `json:"result_count,omitempty"`
+	Pinned      bool                `json:"pinned,omitempty"`
+}
+
+func HistoryKindOf(query string) HistoryKind {
+	switch {
+	case strings.HasPrefix(query, "/channel "), strings.HasPrefix(query, "/shorts "), strings.HasPrefix(query, "/streams "):
+		return HistoryChannel
+	case strings.HasPrefix(query, "/playlist "):
+		return HistoryPlaylist
+	case ExtractVideoID(query) != "":
+		return HistoryURL
+	default:
+		return HistorySearch
+	}
+}
+
+func historyStore() storage.Store {
+	return storage.Store{
+		Path:    GetHistoryFilePath(),
+		Version: historyVersion,
+		Migrate: migrateHistory,
+	}
+}
+
+// migrateHistory converts older histories into entries. Version 0 is plain
+// text with one query per line and version 1 a JSON list of queries.
+func migrateHistory(version int, data []byte) ([]byte, error) {
+	var queries []string
+	switch version {
+	case 0:
+		for _, line := range strings.Split(string(data), "\n") {
+			if trimmed := strings.TrimSpace(line); trimmed != "" {
+				queries = append(queries, trimmed)
+			}
+		}
+	case 1:
+		if err := json.Unmarshal(data, &queries); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown history version %d", version)
+	}
+
+	entries := []HistoryEntry{}
+	for _, query := range queries {
+		entries = append(entries, HistoryEntry{Query: query, Kind: HistoryKindOf(query)})
+	}
+
+	return json.Marshal(entries)
+}
 
 func GetHistoryFilePath() string {
 	dataDir := paths.GetDataDir()
@@ -21,60 +96,87 @@ func GetHistoryFilePath() string {
 	return filepath.Join(dataDir, HistoryFileName)
 }
 
-func LoadHistory() ([]string, error) {
-	path := GetHistoryFilePath()
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
-		}
+func LoadHistory() ([]HistoryEntry, error) {
+	history := []HistoryEntry{}
+	if err := historyStore().Load(&history); err != nil {
 		return nil, err
 	}
 
-	content := string(data)
-	lines := strings.Split(content, "\n")
-
-	var history []string
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed != "" {
-			history = append(history, trimmed)
-		}
-	}
-
 	return history, nil
 }
 
-func SaveHistory(query string) error {
-	if query == "" {
+func updateHistory(fn func(history []HistoryEntry) []HistoryEntry) error {
+	var history []HistoryEntry
+	return historyStore().Update(&history, func() error {
+		history = fn(history)
 		return nil
-	}
+	})
+}
 
-	query = strings.TrimSpace(query)
-	path := GetHistoryFilePath()
+func AddToHistory(entry HistoryEntry) error {
+	entry.Query = strings.TrimSpace(entry.Query)
+	if entry.Query == "" {
+		return nil
+	}
 
-	history, err := LoadHistory()
-	if err != nil {
-		return err
+	if entry.Kind == "" {
+		entry.Kind = HistoryKindOf(entry.Query)
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
 	}
 
-	var newHistory []string
-	for _, entry := range history {
-		if entry != query {
-			newHistory = append(newHistory, entry)
+	return updateHistory(func(history []HistoryEntry) []HistoryEntry {
+		newHistory := []HistoryEntry{}
+		for _, e := range history {
+			if e.Query == entry.Query {
+				entry.Pinned = e.Pinned
+				continue
+			}
+			if len(newHistory) < maxHistory-1 || e.Pinned {
+				newHistory = append(newHistory, e)
+			}
 		}
-	}
 
-	newHistory = append([]string{query}, newHistory...)
+		return append([]HistoryEntry{entry}, newHistory...)
+	})
+}
 
-	if len(newHistory) > 1000 {
-		newHistory = newHistory[:1000]
-	}
+func SetHistoryResultCount(query string, count int) error {
+	return updateHistory(func(history []HistoryEntry) []HistoryEntry {
+		for i, e := range history {
+			if e.Query == query {
+				history[i].ResultCount = count
+				break
+			}
+		}
+
+		return history
+	})
+}
+
+func SetHistoryPinned(query string, pinned bool) error {
+	return updateHistory(func(history []HistoryEntry) []HistoryEntry {
+		for i, e := range history {
+			if e.Query == query {
+				history[i].Pinned = pinned
+				break
+			}
+		}
 
-	content := strings.Join(newHistory, "\n")
-	return os.WriteFile(path, []byte(content), 0o644)
+		return history
+	})
 }
 
-func AddToHistory(query string) error {
-	return SaveHistory(query)
+func RemoveFromHistory(query string) error {
+	return updateHistory(func(history []HistoryEntry) []HistoryEntry {
+		newHistory := []HistoryEntry{}
+		for _, e := range history {
+			if e.Query != query {
+				newHistory = append(newHistory, e)
+			}
+		}
+
+		return newHistory
+	})
 }
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/xdagiz/xytz/internal/paths"
+	"github.com/xdagiz/xytz/internal/storage"
+	"github.com/xdagiz/xytz/internal/types"
+)
+
+const (
+	LibraryFileName = "library.json"
+	libraryVersion  = 1
+)
+
+type LibraryEntry struct {
+	VideoID      string    `json:"video_id"`
+	Title        string    `json:"title"`
+	Channel      string    `json:"channel"`
+	URL          string    `json:"url"`
+	FormatID     string    `json:"format_id"`
+	FilePath     string    `json:"file_path"`
+	Size         int64     `json:"size"`
+	Duration     float64   `json:"duration"`
+	StartedAt    time.Time `json:"started_at"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+func (e LibraryEntry) Exists() bool {
+	_, err := os.Stat(e.FilePath)
+	return err == nil
+}
+
+func libraryStore() storage.Store {
+	return storage.Store{Path: GetLibraryFilePath(), Version: libraryVersion}
+}
+
+func GetLibraryFilePath() string {
+	dataDir := paths.GetDataDir()
+	if err := paths.EnsureDirExists(dataDir); err != nil {
+		log.Printf("Warning: Could not create data directory: %v", err)
+		return LibraryFileName
+	}
+
+	return filepath.Join(dataDir, LibraryFileName)
+}
+
+func LoadLibrary() ([]LibraryEntry, error) {
+	entries := []LibraryEntry{}
+	if err := libraryStore().Load(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func sortLibrary(entries []LibraryEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DownloadedAt.After(entries[j].DownloadedAt)
+	})
+}
+
+func AddToLibrary(newEntries ...LibraryEntry) error {
+	var entries []LibraryEntry
+	return libraryStore().Update(&entries, func() error {
+		for _, entry := range newEntries {
+			replaced := false
+			for i, e := range entries {
+				if e.FilePath == entry.FilePath {
+					entries[i] = entry
+					replaced = true
+					break
+				}
+			}
+
+			if !replaced {
+				entries = append(entries, entry)
+			}
+		}
+
+		sortLibrary(entries)
+		return nil
+	})
+}
+
+func RemoveFromLibrary(filePath string, deleteFile bool) error {
+	if deleteFile {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	var entries []LibraryEntry
+	return libraryStore().Update(&entries, func() error {
+		newEntries := []LibraryEntry{}
+		for _, e := range entries {
+			if e.FilePath != filePath {
+				newEntries = append(newEntries, e)
+			}
+		}
+
+		entries = newEntries
+		return nil
+	})
+}
+
+func FindInLibrary(videoID string) []LibraryEntry {
+	if videoID == "" {
+		return nil
+	}
+
+	entries, err := LoadLibrary()
+	if err != nil {
+		log.Printf("Failed to load library: %v", err)
+		return nil
+	}
+
+	var found []LibraryEntry
+	for _, e := range entries {
+		if e.VideoID == videoID && e.Exists() {
+			found = append(found, e)
+		}
+	}
+
+	return found
+}
+
+func libraryEntries(video types.VideoItem, req types.DownloadRequest, files []types.DownloadedFile, startedAt time.Time) []LibraryEntry {
+	now := time.Now()
+
+	var entries []LibraryEntry
+	for _, file := range files {
+		entry := LibraryEntry{
+			VideoID:      file.VideoID,
+			Title:        file.Title,
+			Channel:      file.Channel,
+			URL:          req.URL,
+			FormatID:     req.FormatID,
+			FilePath:     file.Path,
+			Duration:     file.Duration,
+			StartedAt:    startedAt,
+			DownloadedAt: now,
+		}
+
+		if len(files) == 1 {
+			if entry.VideoID == "" {
+				entry.VideoID = video.ID
+			}
+			if entry.Title == "" {
+				entry.Title = req.Title
+			}
+			if entry.Channel == "" {
+				entry.Channel = video.Channel
+			}
+			if entry.Duration == 0 {
+				entry.Duration = video.Duration
+			}
+		}
+
+		if entry.VideoID == "" && !req.Collection {
+			entry.VideoID = ExtractVideoID(req.URL)
+		}
+
+		if entry.VideoID != "" {
+			entry.URL = "https://www.youtube.com/watch?v=" + entry.VideoID
+		}
+
+		if info, err := os.Stat(file.Path); err == nil {
+			entry.Size = info.Size()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xdagiz/xytz/internal/types"
+
+	"github.com/charmbracelet/bubbles/list"
 )
 
 func extractAfterDelimiter(s, delimiter string, trailingDelimiters ...string) string {
@@ -75,6 +78,143 @@ func ExtractChannelUsername(input string) string {
 	return input
 }
 
+func ParseSearchItem(line string) (list.Item, error) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(line), &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	switch searchItemKind(data) {
+	case "channel":
+		return parseChannelItem(data)
+	case "playlist":
+		return parsePlaylistItem(data)
+	default:
+		return ParseVideoItem(line)
+	}
+}
+
+func searchItemKind(data map[string]any) string {
+	if ieKey, _ := data["ie_key"].(string); ieKey != "" && ieKey != "YoutubeTab" {
+		return "video"
+	}
+
+	url, _ := data["url"].(string)
+	id, _ := data["id"].(string)
+
+	switch {
+	case strings.Contains(url, "list="), strings.Contains(url, "/playlist"):
+		return "playlist"
+	case strings.Contains(url, "/channel/"), strings.Contains(url, "/@"), strings.Contains(url, "/c/"):
+		return "channel"
+	case strings.HasPrefix(id, "UC") && len(id) == 24:
+		return "channel"
+	case strings.HasPrefix(id, "PL"), strings.HasPrefix(id, "OLAK5uy_"):
+		return "playlist"
+	default:
+		return "video"
+	}
+}
+
+func parseChannelItem(data map[string]any) (types.ChannelItem, error) {
+	name, _ := data["title"].(string)
+	if name == "" {
+		name, _ = data["channel"].(string)
+	}
+	if name == "" {
+		return types.ChannelItem{}, fmt.Errorf("missing name in channel data")
+	}
+
+	id, _ := data["channel_id"].(string)
+	if id == "" {
+		id, _ = data["id"].(string)
+	}
+	if id == "" {
+		return types.ChannelItem{}, fmt.Errorf("missing channel ID in channel data")
+	}
+
+	var handle string
+	if uploaderID, _ := data["uploader_id"].(string); strings.HasPrefix(uploaderID, "@") {
+		handle = strings.TrimPrefix(uploaderID, "@")
+	} else if url, _ := data["url"].(string); strings.Contains(url, "/@") {
+		handle = ExtractChannelUsername(url)
+	}
+
+	var subscribers, videoCount float64
+	if v, ok := data["channel_follower_count"]; ok && v != nil {
+		subscribers = parseFloat(v)
+	}
+	if v, ok := data["playlist_count"]; ok && v != nil {
+		videoCount = parseFloat(v)
+	}
+
+	parts := []string{"Channel"}
+	if subscribers > 0 {
+		parts = append(parts, FormatNumber(subscribers)+" subscribers")
+	}
+	if videoCount > 0 {
+		parts = append(parts, FormatNumber(videoCount)+" videos")
+	}
+	if handle != "" {
+		parts = append(parts, "@"+handle)
+	}
+
+	return types.ChannelItem{
+		ID:          id,
+		Name:        name,
+		Handle:      handle,
+		Desc:        strings.Join(parts, " • "),
+		Subscribers: subscribers,
+		VideoCount:  videoCount,
+	}, nil
+}
+
+func parsePlaylistItem(data map[string]any) (types.PlaylistItem, error) {
+	title, _ := data["title"].(string)
+	if title == "" {
+		return types.PlaylistItem{}, fmt.Errorf("missing title in playlist data")
+	}
+
+	id, _ := data["id"].(string)
+	if id == "" {
+		if url, _ := data["url"].(string); strings.Contains(url, "list=") {
+			id = strings.TrimPrefix(PlaylistURL(url), "https://www.youtube.com/playlist?list=")
+		}
+	}
+	if id == "" {
+		return types.PlaylistItem{}, fmt.Errorf("missing playlist ID in playlist data")
+	}
+
+	channel, _ := data["uploader"].(string)
+	if channel == "" {
+		channel, _ = data["channel"].(string)
+	}
+	if len(channel) > 30 {
+		channel = channel[:27] + "..."
+	}
+
+	var videoCount float64
+	if v, ok := data["playlist_count"]; ok && v != nil {
+		videoCount = parseFloat(v)
+	}
+
+	parts := []string{"Playlist"}
+	if videoCount > 0 {
+		parts = append(parts, FormatNumber(videoCount)+" videos")
+	}
+	if channel != "" {
+		parts = append(parts, channel)
+	}
+
+	return types.PlaylistItem{
+		ID:            id,
+		PlaylistTitle: title,
+		Channel:       channel,
+		Desc:          strings.Join(parts, " • "),
+		VideoCount:    videoCount,
+	}, nil
+}
+
 func ParseVideoItem(line string) (types.VideoItem, error) {
 	var data map[string]any
 	if err := json.Unmarshal([]byte(line), &data); err != nil {
@@ -111,32 +251,72 @@ func ParseVideoItem(line string) (types.VideoItem, error) {
 		durationFloat = parseFloat(d)
 	}
 
-	if durationFloat == 0 {
-		return types.VideoItem{}, fmt.Errorf("skipping live/short content with zero duration")
-	}
-
-	viewsStr := FormatNumber(viewCountFloat)
-	durationStr := FormatDuration(durationFloat)
-
 	channelLen := len(channel)
 	if channelLen > 30 {
 		channel = channel[:27] + "..."
 	}
 
-	desc := fmt.Sprintf("%s • %s views • %s", durationStr, viewsStr, channel)
+	kind := videoKind(data)
 
 	videoItem := types.VideoItem{
 		ID:         videoID,
 		VideoTitle: title,
-		Desc:       desc,
+		Desc:       videoDesc(data, kind, durationFloat, viewCountFloat, channel),
 		Views:      viewCountFloat,
 		Duration:   durationFloat,
 		Channel:    channel,
+		Kind:       kind,
 	}
 
 	return videoItem, nil
 }
 
+func videoKind(data map[string]any) types.VideoKind {
+	switch liveStatus, _ := data["live_status"].(string); liveStatus {
+	case "is_live":
+		return types.VideoKindLive
+	case "is_upcoming":
+		return types.VideoKindUpcoming
+	}
+
+	if isLive, _ := data["is_live"].(bool); isLive {
+		return types.VideoKindLive
+	}
+
+	for _, field := range []string{"url", "webpage_url"} {
+		if url, _ := data[field].(string); strings.Contains(url, "/shorts/") {
+			return types.VideoKindShort
+		}
+	}
+
+	return types.VideoKindRegular
+}
+
+func videoDesc(data map[string]any, kind types.VideoKind, duration, views float64, channel string) string {
+	var parts []string
+	switch kind {
+	case types.VideoKindLive:
+		if watching, ok := data["concurrent_view_count"]; ok && watching != nil {
+			parts = append(parts, FormatNumber(parseFloat(watching))+" watching")
+		}
+	case types.VideoKindUpcoming:
+		if start, ok := data["release_timestamp"]; ok && start != nil {
+			parts = append(parts, "starts "+time.Unix(int64(parseFloat(start)), 0).Format("Jan 2 15:04"))
+		}
+	default:
+		if duration > 0 {
+			parts = append(parts, FormatDuration(duration))
+		}
+		parts = append(parts, FormatNumber(views)+" views")
+	}
+
+	if channel != "" {
+		parts = append(parts, channel)
+	}
+
+	return strings.Join(parts, " • ")
+}
+
 func parseFloat(v any) float64 {
 	switch val := v.(type) {
 	case json.Number:
@@ -2,26 +2,91 @@ package utils
 
 import (
 	"bufio"
+	"encoding/json"
 	"io"
 	"log"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/xdagiz/xytz/internal/types"
+)
+
+const (
+	progressTemplatePrefix    = "[xytz-progress] "
+	postprocessTemplatePrefix = "[xytz-postprocess] "
+)
+
+var (
+	percentPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`),
+		regexp.MustCompile(`\[download\]\s+(\d+(?:\.\d+)?)%`),
+	}
+	speedPattern       = regexp.MustCompile(`(\d+(?:\.\d+)?[KMG]?i?B/s)`)
+	etaPattern         = regexp.MustCompile(`ETA\s+(\d+:\d+(?::\d+)?)`)
+	itemPattern        = regexp.MustCompile(`\[download\] Downloading (?:item|video) (\d+) of (\d+)`)
+	destinationPattern = regexp.MustCompile(`Destination:\s*(.+)`)
+	formatPattern      = regexp.MustCompile(`(?:format|format_id)\s+(\d+)`)
+	mergePattern       = regexp.MustCompile(`^\[Merger\] Merging formats into "(.+)"$`)
+	convertedPattern   = regexp.MustCompile(`^\[(?:ExtractAudio|VideoConvertor|VideoRemuxer)\] Destination:\s*(.+)$`)
+	downloadedPattern  = regexp.MustCompile(`^\[download\] (.+) has already been downloaded$`)
+
+	ffmpegTimePattern    = regexp.MustCompile(`\btime=(\d+):(\d+):(\d+(?:\.\d+)?)`)
+	ffmpegSizePattern    = regexp.MustCompile(`\bsize=\s*(\d+)(?:kB|KiB)`)
+	ffmpegBitratePattern = regexp.MustCompile(`\bbitrate=\s*(\d+(?:\.\d+)?kbits/s)`)
 )
 
+func ProgressTemplateArgs() []string {
+	return []string{
+		"--progress-template",
+		"download:" + progressTemplatePrefix + "%(info.id)s %(info.vcodec)s %(info.acodec)s %(info.is_live)s %(progress)j",
+		"--progress-template",
+		"postprocess:" + postprocessTemplatePrefix + "%(progress.postprocessor)s %(progress.status)s",
+	}
+}
+
+type templateProgress struct {
+	Status             string   `json:"status"`
+	DownloadedBytes    *float64 `json:"downloaded_bytes"`
+	TotalBytes         *float64 `json:"total_bytes"`
+	TotalBytesEstimate *float64 `json:"total_bytes_estimate"`
+	Speed              *float64 `json:"speed"`
+	Eta                *float64 `json:"eta"`
+	FragmentIndex      *int     `json:"fragment_index"`
+	FragmentCount      *int     `json:"fragment_count"`
+	Elapsed            *float64 `json:"elapsed"`
+	Filename           string   `json:"filename"`
+}
+
 type ProgressParser struct {
-	regex              *regexp.Regexp
 	currentFormat      string
 	currentDestination string
+	itemIndex          int
+	itemCount          int
+	phase              types.DownloadPhase
+	reportedPhase      types.DownloadPhase
+	live               bool
+	mutex              sync.Mutex
+}
+
+var phasePrefixes = []struct {
+	prefix string
+	phase  types.DownloadPhase
+}{
+	{"[Merger]", types.PhaseMerging},
+	{"[ExtractAudio]", types.PhaseExtractingAudio},
+	{"[EmbedSubtitle]", types.PhaseEmbeddingSubtitles},
+	{"[Metadata]", types.PhaseEmbeddingMetadata},
+	{"[EmbedThumbnail]", types.PhaseEmbeddingThumbnail},
+	{"[Fixup", types.PhaseFixingUp},
 }
 
 func NewProgressParser() *ProgressParser {
-	return &ProgressParser{
-		regex: regexp.MustCompile(`(\d+(?:\.\d+)?)%`),
-	}
+	return &ProgressParser{}
 }
 
-func (p *ProgressParser) ReadPipe(pipe io.Reader, sendProgress func(float64, string, string, string, string)) {
+func (p *ProgressParser) ReadPipe(pipe io.Reader, sendProgress func(types.ProgressMsg)) {
 	reader := bufio.NewReader(pipe)
 	var lineBuilder strings.Builder
 
@@ -29,11 +94,7 @@ func (p *ProgressParser) ReadPipe(pipe io.Reader, sendProgress func(float64, str
 		r, _, err := reader.ReadRune()
 		if err != nil {
 			if lineBuilder.Len() > 0 {
-				line := lineBuilder.String()
-				percent, speed, eta, status, destination := p.ParseLine(line)
-				if strings.Contains(line, "[download]") || percent > 0 || speed != "" || eta != "" {
-					sendProgress(percent, speed, eta, status, destination)
-				}
+				p.handleLine(lineBuilder.String(), sendProgress)
 			}
 
 			break
@@ -41,25 +102,9 @@ func (p *ProgressParser) ReadPipe(pipe io.Reader, sendProgress func(float64, str
 
 		switch r {
 		// TODO: test this on windows and remove if not needed
-		case '\r':
-			if lineBuilder.Len() > 0 {
-				line := lineBuilder.String()
-				percent, speed, eta, status, destination := p.ParseLine(line)
-				if strings.Contains(line, "[download]") || percent > 0 || speed != "" || eta != "" {
-					log.Printf("Progress parsed (\\r): %.2f%%, speed: %s, eta: %s, status: %s, destination: %s, line: %s", percent, speed, eta, status, destination, line)
-					sendProgress(percent, speed, eta, status, destination)
-				}
-
-				lineBuilder.Reset()
-			}
-
-		case '\n':
+		case '\r', '\n':
 			if lineBuilder.Len() > 0 {
-				line := lineBuilder.String()
-				percent, speed, eta, status, destination := p.ParseLine(line)
-				if strings.Contains(line, "[download]") || percent > 0 || speed != "" || eta != "" {
-					sendProgress(percent, speed, eta, status, destination)
-				}
+				p.handleLine(lineBuilder.String(), sendProgress)
 				lineBuilder.Reset()
 			}
 
@@ -69,12 +114,179 @@ func (p *ProgressParser) ReadPipe(pipe io.Reader, sendProgress func(float64, str
 	}
 }
 
-func (p *ProgressParser) ParseLine(line string) (percent float64, speed, eta, status, destination string) {
-	percentPatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`),
-		regexp.MustCompile(`\[download\]\s+(\d+(?:\.\d+)?)%`),
+func (p *ProgressParser) handleLine(line string, sendProgress func(types.ProgressMsg)) {
+	p.mutex.Lock()
+	p.parseFinalPath(line)
+	msg, ok := p.parseTemplateLine(line)
+	if !ok {
+		msg, ok = p.parseFFmpegLine(line)
+	}
+	if !ok {
+		msg, ok = p.parseFallbackLine(line)
+	}
+	// yt-dlp announces a post-processor both in its log line and in the
+	// postprocess template, so a phase is only reported once.
+	if ok && msg.Phase.IsPostProcessing() && msg.Phase == p.reportedPhase {
+		ok = false
+	}
+	if ok {
+		p.reportedPhase = msg.Phase
 	}
+	p.mutex.Unlock()
 
+	if ok {
+		sendProgress(msg)
+	}
+}
+
+func (p *ProgressParser) FinalPath() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.currentDestination
+}
+
+func (p *ProgressParser) parseFinalPath(line string) {
+	for _, pattern := range []*regexp.Regexp{mergePattern, convertedPattern, downloadedPattern} {
+		if match := pattern.FindStringSubmatch(line); len(match) > 1 {
+			p.currentDestination = strings.TrimSpace(match[1])
+			return
+		}
+	}
+}
+
+func (p *ProgressParser) parseTemplateLine(line string) (types.ProgressMsg, bool) {
+	if rest, ok := strings.CutPrefix(line, postprocessTemplatePrefix); ok {
+		postprocessor, _, _ := strings.Cut(rest, " ")
+		if phase := phaseForPostprocessor(postprocessor); phase != "" {
+			p.phase = phase
+		}
+
+		msg := p.progressMsg(100, "", "", string(p.phase), p.currentDestination)
+		return msg, true
+	}
+
+	rest, ok := strings.CutPrefix(line, progressTemplatePrefix)
+	if !ok {
+		return types.ProgressMsg{}, false
+	}
+
+	fields := strings.SplitN(rest, " ", 5)
+	if len(fields) != 5 {
+		return types.ProgressMsg{}, false
+	}
+
+	var progress templateProgress
+	if err := json.Unmarshal([]byte(fields[4]), &progress); err != nil {
+		log.Printf("Failed to decode progress template %q: %v", fields[4], err)
+		return types.ProgressMsg{}, false
+	}
+
+	p.live = fields[3] == "True"
+
+	p.phase = types.PhaseDownloading
+	if progress.Filename != "" {
+		p.currentDestination = progress.Filename
+	}
+
+	vcodec, acodec := naToEmpty(fields[1]), naToEmpty(fields[2])
+	switch {
+	case vcodec != "" && vcodec != "none":
+		p.currentFormat = "video"
+	case acodec != "" && acodec != "none":
+		p.currentFormat = "audio"
+	}
+
+	var downloaded, total float64
+	if progress.DownloadedBytes != nil {
+		downloaded = *progress.DownloadedBytes
+	}
+	if progress.TotalBytes != nil {
+		total = *progress.TotalBytes
+	} else if progress.TotalBytesEstimate != nil {
+		total = *progress.TotalBytesEstimate
+	}
+
+	var percent float64
+	switch {
+	case progress.Status == "finished":
+		percent = 100
+	case total > 0:
+		percent = min(100, downloaded/total*100)
+	}
+
+	var speed, eta string
+	if progress.Speed != nil && *progress.Speed > 0 {
+		speed = FormatBytes(*progress.Speed) + "/s"
+	}
+	if progress.Eta != nil {
+		eta = FormatDuration(*progress.Eta)
+	}
+
+	status := "[download]"
+	if p.currentFormat != "" {
+		status = "[download] " + p.currentFormat
+	}
+
+	msg := p.progressMsg(percent, speed, eta, status, p.currentDestination)
+	msg.VideoID = naToEmpty(fields[0])
+	msg.DownloadedBytes = int64(downloaded)
+	msg.TotalBytes = int64(total)
+	if progress.FragmentIndex != nil {
+		msg.FragmentIndex = *progress.FragmentIndex
+	}
+	if progress.FragmentCount != nil {
+		msg.FragmentCount = *progress.FragmentCount
+	}
+	if progress.Elapsed != nil {
+		msg.Elapsed = *progress.Elapsed
+	}
+
+	return msg, true
+}
+
+func (p *ProgressParser) parseFFmpegLine(line string) (types.ProgressMsg, bool) {
+	if !strings.Contains(line, "bitrate=") {
+		return types.ProgressMsg{}, false
+	}
+
+	match := ffmpegTimePattern.FindStringSubmatch(line)
+	if len(match) < 4 {
+		return types.ProgressMsg{}, false
+	}
+
+	hours, _ := strconv.ParseFloat(match[1], 64)
+	minutes, _ := strconv.ParseFloat(match[2], 64)
+	seconds, _ := strconv.ParseFloat(match[3], 64)
+
+	var speed string
+	if bitrate := ffmpegBitratePattern.FindStringSubmatch(line); len(bitrate) > 1 {
+		speed = bitrate[1]
+	}
+
+	p.live = true
+	p.phase = types.PhaseDownloading
+	msg := p.progressMsg(0, speed, "", "[download]", p.currentDestination)
+	msg.Elapsed = hours*3600 + minutes*60 + seconds
+	if size := ffmpegSizePattern.FindStringSubmatch(line); len(size) > 1 {
+		kilobytes, _ := strconv.ParseInt(size[1], 10, 64)
+		msg.DownloadedBytes = kilobytes * 1024
+	}
+
+	return msg, true
+}
+
+func (p *ProgressParser) parseFallbackLine(line string) (types.ProgressMsg, bool) {
+	percent, speed, eta, status, destination := p.ParseLine(line)
+	_, isPhase := phaseForLine(line)
+	if !strings.Contains(line, "[download]") && !isPhase && percent <= 0 && speed == "" && eta == "" {
+		return types.ProgressMsg{}, false
+	}
+
+	return p.progressMsg(percent, speed, eta, status, destination), true
+}
+
+func (p *ProgressParser) ParseLine(line string) (percent float64, speed, eta, status, destination string) {
 	for _, pattern := range percentPatterns {
 		percentMatch := pattern.FindStringSubmatch(line)
 		if len(percentMatch) > 1 {
@@ -85,21 +297,29 @@ func (p *ProgressParser) ParseLine(line string) (percent float64, speed, eta, st
 		}
 	}
 
-	speedPattern := regexp.MustCompile(`(\d+(?:\.\d+)?[KMG]?i?B/s)`)
 	speedMatch := speedPattern.FindStringSubmatch(line)
 	if len(speedMatch) > 1 {
 		speed = speedMatch[1]
 	}
 
-	etaPattern := regexp.MustCompile(`ETA\s+(\d+:\d+(?::\d+)?)`)
 	etaMatch := etaPattern.FindStringSubmatch(line)
 	if len(etaMatch) > 1 {
 		eta = etaMatch[1]
 	}
 
+	if match := itemPattern.FindStringSubmatch(line); len(match) > 2 {
+		p.itemIndex, _ = strconv.Atoi(match[1])
+		p.itemCount, _ = strconv.Atoi(match[2])
+		p.phase = types.PhaseDownloading
+	}
+
+	if phase, ok := phaseForLine(line); ok {
+		p.phase = phase
+		return 100, "", "", string(phase), p.currentDestination
+	}
+
 	if strings.Contains(line, "[download] Destination:") {
-		destPattern := regexp.MustCompile(`Destination:\s*(.+)`)
-		if match := destPattern.FindStringSubmatch(line); len(match) > 1 {
+		if match := destinationPattern.FindStringSubmatch(line); len(match) > 1 {
 			p.currentDestination = strings.TrimSpace(match[1])
 		}
 
@@ -108,12 +328,12 @@ func (p *ProgressParser) ParseLine(line string) (percent float64, speed, eta, st
 		}
 	}
 
-	formatPattern := regexp.MustCompile(`(?:format|format_id)\s+(\d+)`)
 	if match := formatPattern.FindStringSubmatch(line); len(match) > 1 {
 		p.currentFormat = "format " + match[1]
 	}
 
 	if percent > 0 {
+		p.phase = types.PhaseDownloading
 		if p.currentFormat != "" {
 			status = "[download] " + p.currentFormat
 		} else {
@@ -124,6 +344,57 @@ func (p *ProgressParser) ParseLine(line string) (percent float64, speed, eta, st
 	return percent, speed, eta, status, p.currentDestination
 }
 
+func phaseForLine(line string) (types.DownloadPhase, bool) {
+	for _, pp := range phasePrefixes {
+		if strings.HasPrefix(line, pp.prefix) {
+			return pp.phase, true
+		}
+	}
+
+	return "", false
+}
+
+func phaseForPostprocessor(name string) types.DownloadPhase {
+	switch {
+	case strings.Contains(name, "Merger"):
+		return types.PhaseMerging
+	case strings.Contains(name, "ExtractAudio"):
+		return types.PhaseExtractingAudio
+	case strings.Contains(name, "EmbedSubtitle"):
+		return types.PhaseEmbeddingSubtitles
+	case strings.Contains(name, "Metadata"):
+		return types.PhaseEmbeddingMetadata
+	case strings.Contains(name, "EmbedThumbnail"):
+		return types.PhaseEmbeddingThumbnail
+	case strings.Contains(name, "Fixup"):
+		return types.PhaseFixingUp
+	}
+
+	return ""
+}
+
+func naToEmpty(value string) string {
+	if value == "NA" {
+		return ""
+	}
+
+	return value
+}
+
+func (p *ProgressParser) progressMsg(percent float64, speed, eta, status, destination string) types.ProgressMsg {
+	return types.ProgressMsg{
+		Percent:     percent,
+		Speed:       speed,
+		Eta:         eta,
+		Status:      status,
+		Phase:       p.phase,
+		Destination: destination,
+		ItemIndex:   p.itemIndex,
+		ItemCount:   p.itemCount,
+		Live:        p.live,
+	}
+}
+
 func extractFormatFromDestination(line string) string {
 	videoExtensions := map[string]bool{
 		".mp4":  true,
@@ -153,3 +424,11 @@ func extractFormatFromDestination(line string) string {
 
 	return ""
 }
+
+func OverallPercent(itemIndex, itemCount int, percent float64) float64 {
+	if itemCount <= 0 || itemIndex <= 0 {
+		return percent
+	}
+
+	return (float64(itemIndex-1)*100 + percent) / float64(itemCount)
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/xdagiz/xytz/internal/paths"
+	"github.com/xdagiz/xytz/internal/storage"
+	"github.com/xdagiz/xytz/internal/types"
+)
+
+const (
+	RecentFileName = "recent.json"
+	recentVersion  = 1
+	maxRecent      = 500
+)
+
+type RecentAction string
+
+const (
+	RecentOpened     RecentAction = "opened"
+	RecentDownloaded RecentAction = "downloaded"
+)
+
+type RecentEntry struct {
+	Video     types.VideoItem `json:"video"`
+	Action    RecentAction    `json:"action"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+func (e RecentEntry) URL() string {
+	return "https://www.youtube.com/watch?v=" + e.Video.ID
+}
+
+func recentStore() storage.Store {
+	return storage.Store{Path: GetRecentFilePath(), Version: recentVersion}
+}
+
+func GetRecentFilePath() string {
+	dataDir := paths.GetDataDir()
+	if err := paths.EnsureDirExists(dataDir); err != nil {
+		log.Printf("Warning: Could not create data directory: %v", err)
+		return RecentFileName
+	}
+
+	return filepath.Join(dataDir, RecentFileName)
+}
+
+func LoadRecent() ([]RecentEntry, error) {
+	entries := []RecentEntry{}
+	if err := recentStore().Load(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func AddRecent(action RecentAction, videos ...types.VideoItem) error {
+	now := time.Now()
+
+	var added []RecentEntry
+	for _, video := range videos {
+		if video.ID != "" {
+			added = append(added, RecentEntry{Video: video, Action: action, Timestamp: now})
+		}
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	var entries []RecentEntry
+	return recentStore().Update(&entries, func() error {
+		newEntries := added
+		for _, e := range entries {
+			if len(newEntries) >= maxRecent {
+				break
+			}
+			if !containsRecent(added, e) {
+				newEntries = append(newEntries, e)
+			}
+		}
+
+		entries = newEntries
+		return nil
+	})
+}
+
+func containsRecent(entries []RecentEntry, entry RecentEntry) bool {
+	for _, e := range entries {
+		if e.Video.ID == entry.Video.ID && e.Action == entry.Action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func RemoveRecent(videoID string, action RecentAction) error {
+	var entries []RecentEntry
+	return recentStore().Update(&entries, func() error {
+		newEntries := []RecentEntry{}
+		for _, e := range entries {
+			if e.Video.ID != videoID || e.Action != action {
+				newEntries = append(newEntries, e)
+			}
+		}
+
+		entries = newEntries
+		return nil
+	})
+}
+
+func recentVideos(video types.VideoItem, entries []LibraryEntry) []types.VideoItem {
+	var videos []types.VideoItem
+	for _, entry := range entries {
+		if entry.VideoID == "" {
+			continue
+		}
+
+		v := types.VideoItem{
+			ID:         entry.VideoID,
+			VideoTitle: entry.Title,
+			Duration:   entry.Duration,
+			Channel:    entry.Channel,
+		}
+
+		if video.ID == entry.VideoID {
+			v.Desc = video.Desc
+			v.Views = video.Views
+			if v.VideoTitle == "" {
+				v.VideoTitle = video.VideoTitle
+			}
+		}
+
+		videos = append(videos, v)
+	}
+
+	return videos
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/xdagiz/xytz/internal/types"
 )
 
-func executeYTDLP(sm *SearchManager, searchURL string, searchLimit int) any {
+func executeYTDLP(sm *SearchManager, searchURL string, start, searchLimit int) any {
 	cfg, err := config.Load()
 	if err != nil {
 		log.Printf("Warning: Failed to load config, using defaults: %v", err)
@@ -39,7 +39,7 @@ func executeYTDLP(sm *SearchManager, searchURL string, searchLimit int) any {
 		return types.SearchResultMsg{Err: errMsg}
 	}
 
-	playlistItems := fmt.Sprintf("1:%d", searchLimit)
+	playlistItems := fmt.Sprintf("%d:%d", start, start+searchLimit-1)
 
 	var args []string
 	if cfg.CookiesBrowser != "" {
@@ -82,6 +82,7 @@ func executeYTDLP(sm *SearchManager, searchURL string, searchLimit int) any {
 	}
 
 	var videos []list.Item
+	entries := 0
 
 	scanner := bufio.NewScanner(stdout)
 	stderrScanner := bufio.NewScanner(stderr)
@@ -105,13 +106,15 @@ func executeYTDLP(sm *SearchManager, searchURL string, searchLimit int) any {
 			continue
 		}
 
-		videoItem, err := ParseVideoItem(trimmedLine)
+		entries++
+		item, err := ParseSearchItem(trimmedLine)
 		if err != nil {
-			log.Printf("Failed to parse video item: %v", err)
+			log.Printf("Failed to parse search item: %v", err)
 			continue
 		}
 
-		videos = append(videos, videoItem)
+		videos = append(videos, item)
+		sm.send(types.SearchProgressMsg{URL: searchURL, Start: start, Index: start + entries - 1, Item: item})
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -129,8 +132,13 @@ func executeYTDLP(sm *SearchManager, searchURL string, searchLimit int) any {
 		return nil
 	}
 
+	next := 0
+	if entries >= searchLimit {
+		next = start + searchLimit
+	}
+
 	var errMsg string
-	if len(videos) == 0 {
+	if len(videos) == 0 && next == 0 {
 		for _, line := range stderrLines {
 			if strings.Contains(line, "[Errno 101]") || strings.Contains(line, "[Errno -3]") {
 				errMsg = "Please Check Your Internet connection"
@@ -147,9 +155,9 @@ func executeYTDLP(sm *SearchManager, searchURL string, searchLimit int) any {
 			}
 		}
 
-		return types.SearchResultMsg{Err: errMsg}
+		return types.SearchResultMsg{Err: errMsg, URL: searchURL, Start: start}
 	} else {
-		return types.SearchResultMsg{Videos: videos}
+		return types.SearchResultMsg{Videos: videos, URL: searchURL, Start: start, Next: next}
 	}
 }
 
@@ -166,53 +174,70 @@ func PerformSearch(sm *SearchManager, query, sortParam string, searchLimit int)
 		} else {
 			encodedQuery := url.QueryEscape(query)
 			searchURL := "https://www.youtube.com/results?search_query=" + encodedQuery + "&sp=" + sortParam
-			return executeYTDLP(sm, searchURL, searchLimit)
+			return executeYTDLP(sm, searchURL, 1, searchLimit)
 		}
 	})
 }
 
-func PerformChannelSearch(sm *SearchManager, input string, searchLimit int) tea.Cmd {
+func ChannelURL(input string) string {
+	return ChannelTabURL(input, types.ChannelTabVideos)
+}
+
+func ChannelTabURL(input string, tab types.ChannelTab) string {
+	if tab == "" {
+		tab = types.ChannelTabVideos
+	}
+
+	if strings.Contains(input, "youtube.com") {
+		channelURL := strings.TrimSuffix(input, "/")
+		for _, t := range types.ChannelTabs {
+			channelURL = strings.TrimSuffix(channelURL, "/"+string(t))
+		}
+		return channelURL + "/" + string(tab)
+	}
+
+	if len(input) >= 22 && strings.HasPrefix(input, "UC") {
+		return "https://www.youtube.com/channel/" + input + "/" + string(tab)
+	}
+
+	encodedChannel := url.QueryEscape(input)
+	return "https://www.youtube.com/@" + encodedChannel + "/" + string(tab)
+}
+
+func PerformChannelSearch(sm *SearchManager, input string, tab types.ChannelTab, searchLimit int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		var channelURL string
+		return executeYTDLP(sm, ChannelTabURL(input, tab), 1, searchLimit)
+	})
+}
+
+func PlaylistURL(query string) string {
+	if strings.Contains(query, "https://www.youtube.com/playlist?list=") {
+		return query
+	}
 
-		if strings.Contains(input, "youtube.com") {
-			channelURL = input
-			if !strings.HasSuffix(channelURL, "/videos") {
-				channelURL = strings.TrimSuffix(channelURL, "/") + "/videos"
+	if strings.Contains(query, "watch?v=") && strings.Contains(query, "list=") {
+		parts := strings.Split(query, "list=")
+		if len(parts) > 1 {
+			playlistID := parts[1]
+			if idx := strings.Index(playlistID, "&"); idx != -1 {
+				playlistID = playlistID[:idx]
 			}
-		} else if len(input) >= 22 && strings.HasPrefix(input, "UC") {
-			channelURL = "https://www.youtube.com/channel/" + input + "/videos"
-		} else {
-			encodedChannel := url.QueryEscape(input)
-			channelURL = "https://www.youtube.com/@" + encodedChannel + "/videos"
+			return "https://www.youtube.com/playlist?list=" + playlistID
 		}
+	}
 
-		return executeYTDLP(sm, channelURL, searchLimit)
-	})
+	return "https://www.youtube.com/playlist?list=" + query
 }
 
 func PerformPlaylistSearch(sm *SearchManager, query string, searchLimit int) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		var playlistURL string
-
-		if strings.Contains(query, "https://www.youtube.com/playlist?list=") {
-			playlistURL = query
-		} else if strings.Contains(query, "watch?v=") && strings.Contains(query, "list=") {
-			parts := strings.Split(query, "list=")
-			if len(parts) > 1 {
-				playlistID := parts[1]
-				if idx := strings.Index(playlistID, "&"); idx != -1 {
-					playlistID = playlistID[:idx]
-				}
-				playlistURL = "https://www.youtube.com/playlist?list=" + playlistID
-			} else {
-				playlistURL = "https://www.youtube.com/playlist?list=" + query
-			}
-		} else {
-			playlistURL = "https://www.youtube.com/playlist?list=" + query
-		}
+		return executeYTDLP(sm, PlaylistURL(query), 1, searchLimit)
+	})
+}
 
-		return executeYTDLP(sm, playlistURL, searchLimit)
+func LoadMoreResults(sm *SearchManager, searchURL string, start, searchLimit int) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		return executeYTDLP(sm, searchURL, start, searchLimit)
 	})
 }
 
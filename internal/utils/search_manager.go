@@ -4,18 +4,39 @@ import (
 	"log"
 	"os/exec"
 	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 type SearchManager struct {
 	cmd      *exec.Cmd
 	mutex    sync.Mutex
 	canceled bool
+	program  *tea.Program
 }
 
 func NewSearchManager() *SearchManager {
 	return &SearchManager{}
 }
 
+func (sm *SearchManager) SetProgram(program *tea.Program) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	sm.program = program
+}
+
+func (sm *SearchManager) send(msg tea.Msg) {
+	sm.mutex.Lock()
+	program := sm.program
+	canceled := sm.canceled
+	sm.mutex.Unlock()
+
+	if program != nil && !canceled {
+		program.Send(msg)
+	}
+}
+
 func (sm *SearchManager) SetCmd(cmd *exec.Cmd) {
 	sm.mutex.Lock()
 	defer sm.mutex.Unlock()
@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xdagiz/xytz/internal/paths"
+	"github.com/xdagiz/xytz/internal/storage"
+	"github.com/xdagiz/xytz/internal/types"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	SubscriptionsFileName = "subscriptions.json"
+	subscriptionsVersion  = 1
+)
+
+const maxSeenIDs = 500
+
+type Subscription struct {
+	Channel     string    `json:"channel"`
+	AddedAt     time.Time `json:"added_at"`
+	LastChecked time.Time `json:"last_checked"`
+	SeenIDs     []string  `json:"seen_ids"`
+}
+
+func (s Subscription) Label() string {
+	if len(s.Channel) >= 22 && strings.HasPrefix(s.Channel, "UC") {
+		return s.Channel
+	}
+
+	return "@" + s.Channel
+}
+
+var errUnchanged = errors.New("unchanged")
+
+func subscriptionsStore() storage.Store {
+	return storage.Store{Path: GetSubscriptionsFilePath(), Version: subscriptionsVersion}
+}
+
+func GetSubscriptionsFilePath() string {
+	dataDir := paths.GetDataDir()
+	if err := paths.EnsureDirExists(dataDir); err != nil {
+		log.Printf("Warning: Could not create data directory: %v", err)
+		return SubscriptionsFileName
+	}
+
+	return filepath.Join(dataDir, SubscriptionsFileName)
+}
+
+func LoadSubscriptions() ([]Subscription, error) {
+	subs := []Subscription{}
+	if err := subscriptionsStore().Load(&subs); err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+func updateSubscriptions(fn func(subs *[]Subscription) error) (bool, error) {
+	var subs []Subscription
+	err := subscriptionsStore().Update(&subs, func() error {
+		return fn(&subs)
+	})
+	if errors.Is(err, errUnchanged) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func Subscribe(channel string) (bool, error) {
+	channel = ExtractChannelUsername(channel)
+	if channel == "" {
+		return false, fmt.Errorf("no channel given")
+	}
+
+	return updateSubscriptions(func(subs *[]Subscription) error {
+		for _, s := range *subs {
+			if strings.EqualFold(s.Channel, channel) {
+				return errUnchanged
+			}
+		}
+
+		*subs = append(*subs, Subscription{Channel: channel, AddedAt: time.Now()})
+		return nil
+	})
+}
+
+func Unsubscribe(channel string) (bool, error) {
+	channel = ExtractChannelUsername(channel)
+
+	return updateSubscriptions(func(subs *[]Subscription) error {
+		newSubs := []Subscription{}
+		for _, s := range *subs {
+			if !strings.EqualFold(s.Channel, channel) {
+				newSubs = append(newSubs, s)
+			}
+		}
+
+		if len(newSubs) == len(*subs) {
+			return errUnchanged
+		}
+
+		*subs = newSubs
+		return nil
+	})
+}
+
+func markChecked(channel string, videoIDs []string) error {
+	_, err := updateSubscriptions(func(subs *[]Subscription) error {
+		markSeen(*subs, channel, videoIDs)
+		return nil
+	})
+
+	return err
+}
+
+func markSeen(subs []Subscription, channel string, videoIDs []string) {
+	for i, s := range subs {
+		if s.Channel != channel {
+			continue
+		}
+
+		seen := make(map[string]bool, len(s.SeenIDs))
+		for _, id := range s.SeenIDs {
+			seen[id] = true
+		}
+
+		ids := append([]string{}, s.SeenIDs...)
+		for _, id := range videoIDs {
+			if !seen[id] {
+				ids = append([]string{id}, ids...)
+			}
+		}
+		if len(ids) > maxSeenIDs {
+			ids = ids[:maxSeenIDs]
+		}
+
+		subs[i].SeenIDs = ids
+		subs[i].LastChecked = time.Now()
+	}
+}
+
+func FetchFeed(sm *SearchManager, limit int) types.FeedResultMsg {
+	subs, err := LoadSubscriptions()
+	if err != nil {
+		return types.FeedResultMsg{Err: fmt.Sprintf("Failed to load subscriptions: %v", err)}
+	}
+
+	if len(subs) == 0 {
+		return types.FeedResultMsg{Err: "No subscriptions yet, add one with /subscribe @channel"}
+	}
+
+	var (
+		perChannel [][]list.Item
+		newIDs     = make(map[string]bool)
+		errs       []string
+	)
+
+	for _, sub := range subs {
+		result, ok := executeYTDLP(sm, ChannelURL(sub.Channel), 1, limit).(types.SearchResultMsg)
+		if !ok {
+			return types.FeedResultMsg{Cancelled: true}
+		}
+
+		if result.Err != "" || len(result.Videos) == 0 {
+			reason := result.Err
+			if reason == "" {
+				reason = "no videos found"
+			}
+			log.Printf("Failed to load feed for %s: %s", sub.Label(), reason)
+			errs = append(errs, sub.Label()+": "+reason)
+			continue
+		}
+
+		seen := make(map[string]bool, len(sub.SeenIDs))
+		for _, id := range sub.SeenIDs {
+			seen[id] = true
+		}
+
+		var ids []string
+		for _, item := range result.Videos {
+			if video, ok := item.(types.VideoItem); ok {
+				ids = append(ids, video.ID)
+				// Everything would be new on the first check, so only
+				// highlight uploads once the channel has been seen before.
+				if len(seen) > 0 && !seen[video.ID] {
+					newIDs[video.ID] = true
+				}
+			}
+		}
+
+		if err := markChecked(sub.Channel, ids); err != nil {
+			log.Printf("Failed to update subscription %s: %v", sub.Channel, err)
+		}
+
+		perChannel = append(perChannel, result.Videos)
+	}
+
+	var videos []list.Item
+	for i := 0; ; i++ {
+		added := false
+		for _, items := range perChannel {
+			if i < len(items) {
+				videos = append(videos, items[i])
+				added = true
+			}
+		}
+
+		if !added {
+			break
+		}
+	}
+
+	msg := types.FeedResultMsg{Videos: videos, NewIDs: newIDs}
+	if len(videos) == 0 {
+		msg.Err = "Failed to load feed: " + strings.Join(errs, "; ")
+	}
+
+	return msg
+}
+
+func PerformFeedSearch(sm *SearchManager, limit int) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		msg := FetchFeed(sm, limit)
+		if msg.Cancelled {
+			return nil
+		}
+
+		return msg
+	})
+}
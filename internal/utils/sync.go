@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/xdagiz/xytz/internal/config"
+	"github.com/xdagiz/xytz/internal/types"
+)
+
+type SyncJob struct {
+	Rule    config.AutoDownloadRule
+	Channel string
+	Request types.DownloadRequest
+}
+
+func SyncJobs(cfg *config.Config) (jobs []SyncJob, errs []error) {
+	var subscriptions []Subscription
+	loadedSubscriptions := false
+
+	for _, rule := range cfg.AutoDownloadRules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		req, err := syncRequest(cfg, rule)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		channels := []string{rule.Channel}
+		if rule.Channel == "" {
+			if !loadedSubscriptions {
+				loadedSubscriptions = true
+				subs, err := LoadSubscriptions()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to load subscriptions: %w", err))
+				}
+				subscriptions = subs
+			}
+
+			channels = nil
+			for _, sub := range subscriptions {
+				channels = append(channels, sub.Channel)
+			}
+		}
+
+		for _, channel := range channels {
+			channel = ExtractChannelUsername(channel)
+			job := SyncJob{Rule: rule, Channel: channel, Request: req}
+			job.Request.URL = ChannelURL(channel)
+			job.Request.Title = "@" + channel
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, errs
+}
+
+func PerformSync() tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		cfg, err := config.Load()
+		if err != nil {
+			cfg = config.GetDefault()
+		}
+
+		jobs, errs := SyncJobs(cfg)
+		msg := types.SyncResultMsg{Errs: errs, NoRules: len(cfg.AutoDownloadRules) == 0}
+		channels := make(map[string]bool)
+		for _, job := range jobs {
+			msg.Requests = append(msg.Requests, job.Request)
+			channels[job.Channel] = true
+		}
+		msg.Channels = len(channels)
+		return msg
+	})
+}
+
+func syncRequest(cfg *config.Config, rule config.AutoDownloadRule) (types.DownloadRequest, error) {
+	req := types.DownloadRequest{
+		FormatID:    cfg.DefaultFormat,
+		OutputPath:  rule.OutputPath,
+		Collection:  true,
+		DateAfter:   rule.UploadedAfter,
+		DateBefore:  rule.UploadedBefore,
+		MatchFilter: matchFilter(rule),
+	}
+
+	switch {
+	case rule.Preset != "":
+		preset, ok := cfg.FindPreset(rule.Preset)
+		if !ok {
+			return req, fmt.Errorf("rule %q: unknown preset %q (available: %s)", rule.Label(), rule.Preset, strings.Join(cfg.PresetNames(), ", "))
+		}
+		req.FormatID = preset.Format
+		req.IsAudioTab = preset.IsAudio()
+		req.AudioFormat = preset.AudioFormat
+	case rule.Format != "":
+		req.FormatID = rule.Format
+	}
+
+	limit := rule.Limit
+	if limit <= 0 {
+		limit = cfg.SearchLimit
+	}
+	req.PlaylistItems = fmt.Sprintf("1:%d", limit)
+
+	options := types.DownloadOptions()
+	for i := range options {
+		switch options[i].ConfigField {
+		case "EmbedSubtitles":
+			options[i].Enabled = cfg.EmbedSubtitles
+		case "EmbedMetadata":
+			options[i].Enabled = cfg.EmbedMetadata
+		case "EmbedChapters":
+			options[i].Enabled = cfg.EmbedChapters
+		case "LiveFromStart":
+			options[i].Enabled = cfg.LiveFromStart
+		case "WaitForVideo":
+			options[i].Enabled = cfg.WaitForVideo
+		}
+	}
+	req.Options = options
+
+	return req, nil
+}
+
+func matchFilter(rule config.AutoDownloadRule) string {
+	var filters []string
+
+	if d, err := time.ParseDuration(rule.MinDuration); err == nil && rule.MinDuration != "" {
+		filters = append(filters, fmt.Sprintf("duration >= %d", int(d.Seconds())))
+	}
+
+	if d, err := time.ParseDuration(rule.MaxDuration); err == nil && rule.MaxDuration != "" {
+		filters = append(filters, fmt.Sprintf("duration <= %d", int(d.Seconds())))
+	}
+
+	if rule.Title != "" {
+		escaped := strings.NewReplacer(`'`, `\'`, `&`, `\&`).Replace(rule.Title)
+		filters = append(filters, fmt.Sprintf("title ~= '%s'", escaped))
+	}
+
+	return strings.Join(filters, " & ")
+}
@@ -1,22 +1,43 @@
 package utils
 
 import (
-	"encoding/json"
 	"log"
-	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/xdagiz/xytz/internal/paths"
+	"github.com/xdagiz/xytz/internal/storage"
+	"github.com/xdagiz/xytz/internal/types"
 )
 
-const UnfinishedFileName = ".xytz_unfinished.json"
+const (
+	UnfinishedFileName = ".xytz_unfinished.json"
+	unfinishedVersion  = 1
+)
 
 type UnfinishedDownload struct {
-	URL       string    `json:"url"`
-	FormatID  string    `json:"format_id"`
-	Title     string    `json:"title"`
-	Timestamp time.Time `json:"timestamp"`
+	URL         string                 `json:"url"`
+	FormatID    string                 `json:"format_id"`
+	Title       string                 `json:"title"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Request     *types.DownloadRequest `json:"request,omitempty"`
+	PartialPath string                 `json:"partial_path,omitempty"`
+}
+
+func (d UnfinishedDownload) DownloadRequest() types.DownloadRequest {
+	if d.Request != nil {
+		return *d.Request
+	}
+
+	return types.DownloadRequest{
+		URL:      d.URL,
+		FormatID: d.FormatID,
+		Title:    d.Title,
+	}
+}
+
+func unfinishedStore() storage.Store {
+	return storage.Store{Path: GetUnfinishedFilePath(), Version: unfinishedVersion}
 }
 
 func GetUnfinishedFilePath() string {
@@ -30,18 +51,8 @@ func GetUnfinishedFilePath() string {
 }
 
 func LoadUnfinished() ([]UnfinishedDownload, error) {
-	path := GetUnfinishedFilePath()
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []UnfinishedDownload{}, nil
-		}
-
-		return nil, err
-	}
-
-	var downloads []UnfinishedDownload
-	if err := json.Unmarshal(data, &downloads); err != nil {
+	downloads := []UnfinishedDownload{}
+	if err := unfinishedStore().Load(&downloads); err != nil {
 		return nil, err
 	}
 
@@ -49,46 +60,50 @@ func LoadUnfinished() ([]UnfinishedDownload, error) {
 }
 
 func SaveUnfinished(downloads []UnfinishedDownload) error {
-	path := GetUnfinishedFilePath()
-	data, err := json.MarshalIndent(downloads, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(path, data, 0o644)
+	return unfinishedStore().Save(downloads)
 }
 
 func AddUnfinished(download UnfinishedDownload) error {
-	downloads, err := LoadUnfinished()
-	if err != nil {
-		return err
-	}
+	var downloads []UnfinishedDownload
+	return unfinishedStore().Update(&downloads, func() error {
+		for i, d := range downloads {
+			if d.URL == download.URL {
+				downloads[i] = download
+				return nil
+			}
+		}
 
-	for i, d := range downloads {
-		if d.URL == download.URL {
-			downloads[i] = download
-			return SaveUnfinished(downloads)
+		downloads = append(downloads, download)
+		return nil
+	})
+}
+
+func SetUnfinishedPartialPath(url, partialPath string) error {
+	var downloads []UnfinishedDownload
+	return unfinishedStore().Update(&downloads, func() error {
+		for i, d := range downloads {
+			if d.URL == url {
+				downloads[i].PartialPath = partialPath
+			}
 		}
-	}
 
-	downloads = append(downloads, download)
-	return SaveUnfinished(downloads)
+		return nil
+	})
 }
 
 func RemoveUnfinished(url string) error {
-	downloads, err := LoadUnfinished()
-	if err != nil {
-		return err
-	}
-
-	var newDownloads []UnfinishedDownload
-	for _, d := range downloads {
-		if d.URL != url {
-			newDownloads = append(newDownloads, d)
+	var downloads []UnfinishedDownload
+	return unfinishedStore().Update(&downloads, func() error {
+		newDownloads := []UnfinishedDownload{}
+		for _, d := range downloads {
+			if d.URL != url {
+				newDownloads = append(newDownloads, d)
+			}
 		}
-	}
 
-	return SaveUnfinished(newDownloads)
+		downloads = newDownloads
+		return nil
+	})
 }
 
 func GetUnfinishedByURL(url string) *UnfinishedDownload {